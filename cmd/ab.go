@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/compare"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	"github.com/charmbracelet/log"
+	"github.com/dlclark/regexp2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	oldRev = new(string)
+	newRev = new(string)
+)
+
+// revisions of an A/B run, also the values of 'revision' label of Benchmark
+const (
+	revisionOld = "old"
+	revisionNew = "new"
+)
+
+// abCmd run Benchmark of two git revisions alternately and compare them
+var abCmd = &cobra.Command{
+	Use:   "ab --old <rev> --new <rev> [--bench <regexp>] [--count <rounds>] [packages] [-- <go test flags>]",
+	Short: "Run Benchmark of two git revisions alternately in rounds and compare them",
+	Long: `Run Benchmark of two git revisions alternately in rounds and compare them.
+running all of the old revision and then all of the new one picks up drift like thermal throttling and noisy neighbours,
+so benchvisual checks out both revisions in temporary git worktrees, builds test binaries of the given packages('./...' in default)
+with 'go test -c', and runs them alternately(old, new, then new, old...) for --count rounds, one sample per round,
+go test flags are passed to test binaries(e.g. -benchtime 1s -> -test.benchtime 1s), except build flags(e.g. -tags, -race, -gcflags)
+which are passed to 'go test -c', and -count which is taken by rounds.
+the comparison of every metric(Welch's t-test at --confidence, at least --min-change) is printed and saved to ab_comparison.txt,
+and Benchmark of both revisions are rendered with a 'revision' label(old / new), which is the series of charts in default.
+with --history, the runs of the old and new revision are appended as two entries, each with its own commit.`,
+	Example: `  benchvisual ab --old main --new HEAD --bench 'AllRandFloat64' -s '/' ./internal/...
+  benchvisual ab --old v1.2.0 --count 20 --format json -s '/' -- -benchtime 100000x`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if flags := cmd.Flags(); !flags.Changed("series") && !flags.Changed("split-by") &&
+			layout.Series == visual.DefaultLayout.Series && layout.Split == "" {
+			// old and new side by side, one chart per target, unless the layout is given
+			layout.Series, layout.Split = "revision", "target"
+		}
+		regex, err := prepare()
+		if err != nil {
+			return err
+		}
+		if *benchCount < 2 {
+			return fmt.Errorf("--count should be at least 2 to compare samples, got %d", *benchCount)
+		}
+
+		pkgs, flags := args, []string(nil)
+		if dashIdx := cmd.ArgsLenAtDash(); dashIdx != -1 {
+			pkgs, flags = args[:dashIdx], args[dashIdx:]
+		}
+		buildFlags, flags := runner.SplitBuildFlags(flags)
+		for _, flag := range flags {
+			if runner.FlagName(flag) == "count" {
+				return fmt.Errorf("go test flag %s is not allowed, every round takes one sample, use --count for rounds", flag)
+			}
+		}
+		goTest, err := runner.NewGoTest(pkgs, nil)
+		if err != nil {
+			return err
+		}
+		testFlags := append([]string{"-test.run=^$", "-test.bench=" + *benchPattern, "-test.benchmem", "-test.count=1"}, runner.TestBinaryFlags(flags)...)
+
+		ctx := cmd.Context()
+		binDir, err := os.MkdirTemp("", "benchvisual-ab-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(binDir)
+		revisions := []string{revisionOld, revisionNew}
+		binaries := make(map[string][]runner.TestBinary)
+		commits := make(map[string]string)
+		for idx, rev := range []string{*oldRev, *newRev} {
+			revision := revisions[idx]
+			var worktree *runner.Worktree
+			commits[revision], worktree, binaries[revision], err = buildRevision(ctx, goTest, rev, buildFlags, filepath.Join(binDir, revision))
+			if err != nil {
+				return fmt.Errorf("error when build %s revision %s: %w", revision, rev, err)
+			}
+			// binaries run in package directories of the worktree, with test data of their revision
+			defer func() {
+				if removeErr := worktree.Remove(context.Background()); removeErr != nil {
+					log.Warn("failed to remove worktree", "dir", worktree.Dir, "err", removeErr)
+				}
+			}()
+		}
+
+		pairs := pairBinaries(binaries[revisionOld], binaries[revisionNew])
+		if len(pairs) == 0 {
+			return fmt.Errorf("no package with tests in both revisions")
+		}
+		goVersion, err := runner.GoVersion(ctx, goTest.GoBin)
+		if err != nil {
+			log.Warn("failed to get go version", "err", err)
+		}
+		startTime := time.Now()
+		sets := make(map[string][]bench.Set)
+		for round := 0; round < *benchCount; round++ {
+			for _, pair := range pairs {
+				for _, revision := range roundOrder(round) {
+					binary := pair[revision]
+					parsed, err := runTestBinary(ctx, &binary, testFlags, regex)
+					if err != nil {
+						return fmt.Errorf("error when run %s revision of %s: %w", revision, binary.Pkg, err)
+					}
+					sets[revision] = append(sets[revision], parsed...)
+				}
+			}
+			log.Info("A/B round finished", "round", round+1, "rounds", *benchCount)
+		}
+
+		for _, revision := range revisions {
+			sets[revision] = bench.MergeSets(sets[revision])
+			for idx := range sets[revision] {
+				set := &sets[revision][idx]
+				set.Command = strings.Join(append([]string{strings.ReplaceAll(set.Pkg, "/", "_") + ".test"}, runner.QuoteArgs(testFlags)...), " ")
+				set.GoVersion = goVersion
+				set.Commit = commits[revision]
+				set.Date = startTime.Format(time.RFC3339)
+			}
+		}
+		// outliers are detected in runs of each revision
+		oldSets, err := refine(sets[revisionOld], true)
+		if err != nil {
+			return err
+		}
+		newSets, err := refine(sets[revisionNew], true)
+		if err != nil {
+			return err
+		}
+
+		buffer := new(bytes.Buffer)
+		rows := compare.Sets(oldSets, newSets)
+		if err = compare.WriteText(buffer, rows, *confidence, *minChange); err != nil {
+			return err
+		}
+		fmt.Print(buffer.String())
+		comparisonPath := filepath.Join(*outputDir, "ab_comparison.txt")
+		if err = os.WriteFile(comparisonPath, buffer.Bytes(), os.ModePerm); err != nil {
+			return err
+		}
+		log.Info("A/B comparison saved", "old", commits[revisionOld], "new", commits[revisionNew], "saved path", comparisonPath)
+
+		labelRevision(oldSets, revisionOld)
+		labelRevision(newSets, revisionNew)
+		if err = emit(ctx, bench.MergeSets(append(oldSets, newSets...)), false); err != nil {
+			return err
+		}
+		if *historyPath != "" {
+			return recordRevisions(ctx, *historyPath, oldSets, newSets)
+		}
+		return nil
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// buildRevision check out rev in a temporary worktree and build test binaries of the packages into outDir,
+// the worktree is kept for binaries to run in, and should be removed by the caller
+//
+//	@param ctx context.Context
+//	@param goTest *runner.GoTest packages to build, relative to the working directory
+//	@param rev string
+//	@param buildFlags []string build flags passed to 'go test -c', e.g. -tags
+//	@param outDir string
+//	@return commit string commit of the revision
+//	@return worktree *runner.Worktree
+//	@return binaries []runner.TestBinary
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 00:38:52
+func buildRevision(ctx context.Context, goTest *runner.GoTest, rev string, buildFlags []string, outDir string) (commit string, worktree *runner.Worktree,
+	binaries []runner.TestBinary, err error) {
+	// resolve revisions in the working directory, HEAD in the worktree is another one
+	if commit, err = runner.Git(ctx, ".", "rev-parse", "--verify", rev+"^{commit}"); err != nil {
+		return "", nil, nil, err
+	}
+	prefix, err := runner.Git(ctx, ".", "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if worktree, err = runner.NewWorktree(ctx, ".", commit); err != nil {
+		return "", nil, nil, err
+	}
+	log.Info("building test binaries", "revision", rev, "commit", commit, "worktree", worktree.Dir)
+	if binaries, err = runner.BuildTestBinaries(ctx, goTest.GoBin, filepath.Join(worktree.Dir, prefix), goTest.Pkgs, buildFlags, outDir); err != nil {
+		return "", nil, nil, errors.Join(err, worktree.Remove(context.Background()))
+	}
+	return commit, worktree, binaries, nil
+}
+
+// recordRevisions append runs of the old and new revision to the history file as two entries, each with its own commit,
+// as history is keyed without revision, samples of both revisions in one entry would be averaged together
+func recordRevisions(ctx context.Context, path string, oldSets, newSets []bench.Set) (err error) {
+	for _, revisionSets := range [][]bench.Set{oldSets, newSets} {
+		if len(revisionSets) == 0 {
+			continue
+		}
+		if err = appendHistory(ctx, path, revisionSets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// roundOrder order of revisions to run in the round, alternated so that neither revision always runs first
+func roundOrder(round int) []string {
+	if round%2 == 1 {
+		return []string{revisionNew, revisionOld}
+	}
+	return []string{revisionOld, revisionNew}
+}
+
+// pairBinaries pair test binaries of the old and new revision by package, packages missing in either side are left out
+func pairBinaries(oldBinaries, newBinaries []runner.TestBinary) (pairs []map[string]runner.TestBinary) {
+	newOf := make(map[string]runner.TestBinary, len(newBinaries))
+	for _, binary := range newBinaries {
+		newOf[binary.Pkg] = binary
+	}
+	for _, binary := range oldBinaries {
+		newBinary, ok := newOf[binary.Pkg]
+		if !ok {
+			log.Warn("package only has tests in the old revision, skip it", "pkg", binary.Pkg)
+			continue
+		}
+		delete(newOf, binary.Pkg)
+		pairs = append(pairs, map[string]runner.TestBinary{revisionOld: binary, revisionNew: newBinary})
+	}
+	for pkg := range newOf {
+		log.Warn("package only has tests in the new revision, skip it", "pkg", pkg)
+	}
+	return
+}
+
+// runTestBinary run a test binary and parse its Benchmark output
+//
+//	@param ctx context.Context
+//	@param binary *runner.TestBinary
+//	@param flags []string
+//	@param regex *regexp2.Regexp
+//	@return sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func runTestBinary(ctx context.Context, binary *runner.TestBinary, flags []string, regex *regexp2.Regexp) (sets []bench.Set, err error) {
+	output := new(bytes.Buffer)
+	if err = binary.Run(ctx, flags, output, os.Stderr); err != nil {
+		os.Stdout.Write(output.Bytes())
+		return nil, err
+	}
+	return bench.Parse(bufio.NewReader(output), *sep, regex, scanOptions()...)
+}
+
+// labelRevision label every Benchmark of sets with its revision
+func labelRevision(sets []bench.Set, revision string) {
+	for _, set := range sets {
+		for target := range set.Targets {
+			for idx := range set.Targets[target] {
+				benchmark := &set.Targets[target][idx]
+				if benchmark.Labels == nil {
+					benchmark.Labels = make(map[string]string)
+				}
+				benchmark.Labels["revision"] = revision
+			}
+		}
+	}
+}
+
+func init() {
+	abCmd.Flags().StringVar(oldRev, "old", "", "git revision to compare with")
+	abCmd.Flags().StringVar(newRev, "new", "HEAD", "git revision to compare")
+	abCmd.Flags().StringVar(benchPattern, "bench", ".", "regexp of Benchmark to run, passed to test binaries as -test.bench")
+	abCmd.Flags().IntVar(benchCount, "count", 10, "rounds to run, every round takes one sample of every Benchmark of both revisions")
+	abCmd.Flags().Float64Var(confidence, "confidence", 0.99, "confidence of Welch's t-test for a change to be significant")
+	abCmd.Flags().Float64Var(minChange, "min-change", 0.02, "minimal relative change of the mean to be significant, e.g. 0.02 for 2%")
+	_ = abCmd.MarkFlagRequired("old")
+
+	rootCmd.AddCommand(abCmd)
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/history"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPairBinaries(t *testing.T) {
+	convey.Convey("Pair test binaries of both revisions by package", t, func() {
+		oldBinaries := []runner.TestBinary{{Pkg: "demo/a", Path: "old/a"}, {Pkg: "demo/removed", Path: "old/removed"}, {Pkg: "demo/b", Path: "old/b"}}
+		newBinaries := []runner.TestBinary{{Pkg: "demo/b", Path: "new/b"}, {Pkg: "demo/added", Path: "new/added"}, {Pkg: "demo/a", Path: "new/a"}}
+		pairs := pairBinaries(oldBinaries, newBinaries)
+		convey.So(pairs, convey.ShouldResemble, []map[string]runner.TestBinary{
+			{revisionOld: oldBinaries[0], revisionNew: newBinaries[2]},
+			{revisionOld: oldBinaries[2], revisionNew: newBinaries[0]},
+		})
+		convey.So(pairBinaries(oldBinaries[1:2], newBinaries[1:2]), convey.ShouldBeEmpty)
+	})
+}
+
+func TestRoundOrder(t *testing.T) {
+	convey.Convey("Alternate the order of revisions in rounds", t, func() {
+		convey.So(roundOrder(0), convey.ShouldResemble, []string{revisionOld, revisionNew})
+		convey.So(roundOrder(1), convey.ShouldResemble, []string{revisionNew, revisionOld})
+		convey.So(roundOrder(2), convey.ShouldResemble, []string{revisionOld, revisionNew})
+	})
+}
+
+func TestRecordRevisions(t *testing.T) {
+	convey.Convey("Given Benchmark of both revisions of an A/B run", t, func() {
+		revisionSets := func(commit string, nsPerOp float64) []bench.Set {
+			return []bench.Set{{Pkg: "demo", Commit: commit, Date: "2026-10-20T02:00:00Z", Targets: map[string]bench.BenchmarkList{
+				"Fib": {{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", Runs: 1000, NsPerOp: nsPerOp}},
+			}}}
+		}
+		path := filepath.Join(t.TempDir(), "history.jsonl")
+
+		convey.Convey("Each revision is appended to history as a run of its own commit", func() {
+			convey.So(recordRevisions(context.Background(), path, revisionSets("0ld", 100), revisionSets("new", 200)), convey.ShouldBeNil)
+			entries, err := history.Load(path)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(entries, convey.ShouldHaveLength, 2)
+			trends := history.Trends(entries)
+			convey.So(trends, convey.ShouldHaveLength, 1)
+			convey.So(trends[0].Points[0].Commit, convey.ShouldEqual, "0ld")
+			convey.So(trends[0].Points[0].Metrics["ns/op"], convey.ShouldEqual, 100)
+			convey.So(trends[0].Points[1].Commit, convey.ShouldEqual, "new")
+			convey.So(trends[0].Points[1].Metrics["ns/op"], convey.ShouldEqual, 200)
+		})
+	})
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/history"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/Kevinello/benchvisual/internal/stats"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	goodRev      = new(string)
+	badRev       = new(string)
+	benchPattern = new(string)
+	benchCount   = new(int)
+	bisectUnit   = new(string)
+	confidence   = new(float64)
+	minChange    = new(float64)
+)
+
+// bisectCmd find the commit introducing a Benchmark regression by git bisect
+var bisectCmd = &cobra.Command{
+	Use:   "bisect --good <rev> --bad <rev> [--bench <regexp>] [packages] [-- <go test flags>]",
+	Short: "Find the commit introducing a Benchmark regression by git bisect",
+	Long: `Find the commit introducing a Benchmark regression by git bisect.
+benchvisual checks out commits in a temporary git worktree(the working directory is never touched),
+and runs the selected Benchmark with 'go test -run ^$ -bench <regexp> -benchmem -count <count>' in the given packages('./...' in default).
+the good and bad revisions are measured first, Benchmark whose --metric regresses significantly between them
+(Welch's t-test of the samples at --confidence, by at least --min-change) are tracked,
+then every commit checked out by 'git bisect' is bad when any tracked Benchmark regresses significantly from the good revision,
+and skipped when it can not be benchmarked(e.g. it does not build).
+the first bad commit is reported, and the results of every step are rendered to bisect-<pkg>.html.`,
+	Example: `  benchvisual bisect --good v1.2.0 --bad HEAD --bench 'AllRandFloat64' -s '/' ./internal/...
+  benchvisual bisect --good HEAD~20 --bad HEAD --bench 'Encode' --metric allocs/op --count 5 -- -benchtime 100000x`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		regex, err := prepare()
+		if err != nil {
+			return err
+		}
+		if *benchCount < 2 {
+			return fmt.Errorf("--count should be at least 2 to compare samples, got %d", *benchCount)
+		}
+
+		pkgs, flags := args, []string(nil)
+		if dashIdx := cmd.ArgsLenAtDash(); dashIdx != -1 {
+			pkgs, flags = args[:dashIdx], args[dashIdx:]
+		}
+		flags = append([]string{"-run", "^$", "-bench", *benchPattern, "-benchmem", "-count", strconv.Itoa(*benchCount)}, flags...)
+		goTest, err := runner.NewGoTest(pkgs, flags)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		// packages are relative to the working directory, which is kept in the worktree
+		prefix, err := runner.Git(ctx, ".", "rev-parse", "--show-prefix")
+		if err != nil {
+			return err
+		}
+		// resolve revisions in the working directory, HEAD in the worktree is another one
+		good, err := runner.Git(ctx, ".", "rev-parse", "--verify", *goodRev+"^{commit}")
+		if err != nil {
+			return err
+		}
+		bad, err := runner.Git(ctx, ".", "rev-parse", "--verify", *badRev+"^{commit}")
+		if err != nil {
+			return err
+		}
+		worktree, err := runner.NewWorktree(ctx, ".", good)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if removeErr := worktree.Remove(context.Background()); removeErr != nil {
+				log.Warn("failed to remove worktree", "dir", worktree.Dir, "err", removeErr)
+			}
+		}()
+		goTest.Dir = filepath.Join(worktree.Dir, prefix)
+		log.Info("worktree added for bisect", "dir", worktree.Dir)
+
+		bisector := &bisector{
+			run: func(ctx context.Context) ([]bench.Set, error) {
+				return runBenchmark(ctx, goTest, regex, nil)
+			},
+			unit:  *bisectUnit,
+			steps: make(map[history.Key][]history.Point),
+		}
+		firstBad, bisectErr := bisector.bisect(ctx, worktree, good, bad)
+		if len(bisector.tracked) > 0 {
+			var trends []history.Trend
+			for _, key := range bisector.tracked {
+				trends = append(trends, history.Trend{Key: key, Points: bisector.steps[key]})
+			}
+			savedPaths, err := visual.VisualizeBisect(*outputDir, trends)
+			if err != nil {
+				return errors.Join(bisectErr, err)
+			}
+			log.Info("Benchmark bisect visualized success", "saved paths", savedPaths)
+		}
+		if bisectErr != nil {
+			return bisectErr
+		}
+		if len(firstBad) > 1 {
+			log.Warn("only skipped commits are left, the first bad commit could be any of them", "commits", firstBad)
+			return nil
+		}
+		log.Info("first bad commit found", "commit", firstBad[0])
+		return nil
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// bisector state of a Benchmark bisect
+type bisector struct {
+	run  func(ctx context.Context) ([]bench.Set, error) // run the Benchmark in the worktree
+	unit string
+
+	good    map[history.Key][]float64       // samples of the good revision
+	tracked []history.Key                   // Benchmark regressed between the good and bad revision
+	steps   map[history.Key][]history.Point // mean of every measured commit
+}
+
+// bisect measure the good and bad revisions, then drive git bisect in the worktree
+//
+//	@receiver bisector *bisector
+//	@param ctx context.Context
+//	@param worktree *runner.Worktree checked out at the good revision
+//	@param good string commit of the good revision
+//	@param bad string commit of the bad revision
+//	@return firstBad []string the first bad commit, or every candidate of it when only skipped commits are left
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 00:12:35
+func (bisector *bisector) bisect(ctx context.Context, worktree *runner.Worktree, good string, bad string) (firstBad []string, err error) {
+	if bisector.good, err = bisector.measure(ctx, "good"); err != nil {
+		return nil, fmt.Errorf("error when benchmark good revision %s: %w", *goodRev, err)
+	}
+	if err = worktree.Checkout(ctx, bad); err != nil {
+		return nil, err
+	}
+	badSamples, err := bisector.measure(ctx, "bad")
+	if err != nil {
+		return nil, fmt.Errorf("error when benchmark bad revision %s: %w", *badRev, err)
+	}
+
+	if bisector.track(badSamples); len(bisector.tracked) == 0 {
+		return nil, fmt.Errorf("no Benchmark regresses significantly in %s between %s and %s, nothing to bisect", bisector.unit, *goodRev, *badRev)
+	}
+	return runner.Bisect(ctx, worktree.Dir, good, bad, bisector.judge)
+}
+
+// track track Benchmark which regress significantly from the good revision to the bad one, sorted by key
+func (bisector *bisector) track(badSamples map[history.Key][]float64) {
+	for key, goodSamples := range bisector.good {
+		if comparison := stats.Compare(goodSamples, badSamples[key]); regressed(comparison, bisector.unit) {
+			bisector.tracked = append(bisector.tracked, key)
+			log.Info("Benchmark regressed between good and bad revision", "benchmark", key, "change", fmt.Sprintf("%+.2f%%", comparison.Change*100),
+				"p", comparison.P)
+		}
+	}
+	sort.Slice(bisector.tracked, func(i, j int) bool { return bisector.tracked[i].String() < bisector.tracked[j].String() })
+}
+
+// judge benchmark the commit checked out by git bisect, it is bad when any tracked Benchmark regresses from the good revision
+//
+//	@receiver bisector *bisector
+//	@param ctx context.Context
+//	@param commit string
+//	@return verdict runner.Verdict
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func (bisector *bisector) judge(ctx context.Context, commit string) (verdict runner.Verdict, err error) {
+	samples, err := bisector.measure(ctx, "")
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		log.Warn("commit can not be benchmarked, skip it", "commit", commit, "err", err)
+		return runner.VerdictSkip, nil
+	}
+	verdict = runner.VerdictGood
+	for _, key := range bisector.tracked {
+		if len(samples[key]) == 0 {
+			log.Warn("tracked Benchmark is missing in commit, skip it", "commit", commit, "benchmark", key)
+			return runner.VerdictSkip, nil
+		}
+		if regressed(stats.Compare(bisector.good[key], samples[key]), bisector.unit) {
+			verdict = runner.VerdictBad
+		}
+	}
+	log.Info("commit judged", "commit", commit, "verdict", verdict)
+	return verdict, nil
+}
+
+// measure run the Benchmark in the worktree, samples of the metric are returned and the mean is recorded as a step
+//
+//	@receiver bisector *bisector
+//	@param ctx context.Context
+//	@param step string name of the step, good or bad for the revisions given, empty for commits checked out by git bisect
+//	@return samples map[history.Key][]float64
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 00:12:35
+func (bisector *bisector) measure(ctx context.Context, step string) (samples map[history.Key][]float64, err error) {
+	sets, err := bisector.run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sets, err = refine(sets, true); err != nil {
+		return nil, err
+	}
+	samples = sampleSets(sets, bisector.unit)
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no Benchmark reports %s", bisector.unit)
+	}
+
+	commit := ""
+	if len(sets) > 0 {
+		commit = sets[0].Commit
+	}
+	if step != "" {
+		// labeled like a dirty commit, e.g. 0123456-good
+		commit += "-" + step
+	}
+	now := time.Now()
+	for key, values := range samples {
+		bisector.steps[key] = append(bisector.steps[key], history.Point{
+			Timestamp: now,
+			Commit:    commit,
+			Samples:   len(values),
+			Metrics:   map[string]float64{bisector.unit: stats.Mean(values)},
+		})
+	}
+	return samples, nil
+}
+
+// regressed whether the comparison is a significant regression of the metric
+func regressed(comparison stats.Comparison, unit string) bool {
+	if !comparison.Significant(*confidence, *minChange) {
+		return false
+	}
+	return (comparison.Change > 0) != bench.HigherIsBetter(unit)
+}
+
+// sampleSets samples of a metric of every Benchmark(keyed by package, target and scenario), e.g. from runs by -count,
+// runs not reporting the metric are left out, while a reported 0(e.g. 0 allocs/op) is a sample
+//
+//	@param sets []bench.Set
+//	@param unit string
+//	@return samples map[history.Key][]float64
+//	@author kevineluo
+//	@update 2026-10-20 02:31:09
+func sampleSets(sets []bench.Set, unit string) (samples map[history.Key][]float64) {
+	samples = make(map[history.Key][]float64)
+	for _, set := range sets {
+		for _, benchmarks := range set.Targets {
+			for idx := range benchmarks {
+				benchmark := &benchmarks[idx]
+				if benchmark.Reports(unit) {
+					key := history.Key{Pkg: set.Pkg, Target: benchmark.Target, Scenario: benchmark.Scenario}
+					samples[key] = append(samples[key], benchmark.Metrics()[unit])
+				}
+			}
+		}
+	}
+	return
+}
+
+func init() {
+	bisectCmd.Flags().StringVar(goodRev, "good", "", "git revision where the Benchmark is good")
+	bisectCmd.Flags().StringVar(badRev, "bad", "HEAD", "git revision where the Benchmark is regressed")
+	bisectCmd.Flags().StringVar(benchPattern, "bench", ".", "regexp of Benchmark to run, passed to 'go test -bench'")
+	bisectCmd.Flags().IntVar(benchCount, "count", 10, "runs of every Benchmark at every commit, passed to 'go test -count'")
+	bisectCmd.Flags().StringVar(bisectUnit, "metric", "ns/op", "unit of the metric to compare, e.g. ns/op, B/op, allocs/op or a custom metric")
+	bisectCmd.Flags().Float64Var(confidence, "confidence", 0.99, "confidence of Welch's t-test for a sample set to be regressed")
+	bisectCmd.Flags().Float64Var(minChange, "min-change", 0.02, "minimal relative change of the mean to be regressed, e.g. 0.02 for 2%")
+	bisectCmd.Flags().StringVar(rawPath, "raw", "", "file path to tee the raw go test output of the latest step to (default <output dir>/"+rawFileName+")")
+	_ = bisectCmd.MarkFlagRequired("good")
+
+	rootCmd.AddCommand(bisectCmd)
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/history"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// bisectSets Benchmark sets of a commit with ns/op samples of every target
+func bisectSets(commit string, samples map[string][]float64) []bench.Set {
+	set := bench.Set{Pkg: "demo", Commit: commit, Targets: make(map[string]bench.BenchmarkList)}
+	for target, nsPerOps := range samples {
+		for _, nsPerOp := range nsPerOps {
+			set.Targets[target] = append(set.Targets[target], bench.Benchmark{Name: "Benchmark" + target + "/10", Target: target, Scenario: "10", Runs: 1000, NsPerOp: nsPerOp})
+		}
+	}
+	return []bench.Set{set}
+}
+
+func TestBisector(t *testing.T) {
+	convey.Convey("Given a bisector running injected Benchmark samples", t, func() {
+		var (
+			runs   [][]bench.Set
+			runErr error
+		)
+		bisector := &bisector{
+			run: func(ctx context.Context) ([]bench.Set, error) {
+				if runErr != nil {
+					return nil, runErr
+				}
+				sets := runs[0]
+				runs = runs[1:]
+				return sets, nil
+			},
+			unit:  "ns/op",
+			steps: make(map[history.Key][]history.Point),
+		}
+		fib, sleep := history.Key{Pkg: "demo", Target: "Fib", Scenario: "10"}, history.Key{Pkg: "demo", Target: "Sleep", Scenario: "10"}
+		goodFib, badFib := []float64{100, 101, 99, 100, 101, 99}, []float64{150, 151, 149, 150, 151, 149}
+		stable := []float64{50, 51, 49, 50, 51, 49}
+
+		runs = [][]bench.Set{
+			bisectSets("c0", map[string][]float64{"Fib": goodFib, "Sleep": stable}),
+			bisectSets("c9", map[string][]float64{"Fib": badFib, "Sleep": stable}),
+		}
+		var err error
+		bisector.good, err = bisector.measure(context.Background(), "good")
+		convey.So(err, convey.ShouldBeNil)
+		badSamples, err := bisector.measure(context.Background(), "bad")
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("Measured steps are recorded with their commits", func() {
+			convey.So(bisector.steps[fib], convey.ShouldHaveLength, 2)
+			convey.So(bisector.steps[fib][0].Commit, convey.ShouldEqual, "c0-good")
+			convey.So(bisector.steps[fib][1].Commit, convey.ShouldEqual, "c9-bad")
+			convey.So(bisector.steps[fib][1].Metrics["ns/op"], convey.ShouldEqual, 150)
+		})
+
+		convey.Convey("Only Benchmark regressed between the good and bad revision are tracked", func() {
+			bisector.track(badSamples)
+			convey.So(bisector.tracked, convey.ShouldResemble, []history.Key{fib})
+			convey.So(bisector.tracked, convey.ShouldNotContain, sleep)
+
+			convey.Convey("Commits are judged against the good revision", func() {
+				runs = [][]bench.Set{
+					bisectSets("c3", map[string][]float64{"Fib": goodFib, "Sleep": badFib}),
+					bisectSets("c6", map[string][]float64{"Fib": badFib, "Sleep": stable}),
+				}
+				verdict, err := bisector.judge(context.Background(), "c3")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(verdict, convey.ShouldEqual, runner.VerdictGood)
+				verdict, err = bisector.judge(context.Background(), "c6")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(verdict, convey.ShouldEqual, runner.VerdictBad)
+				convey.So(bisector.steps[fib], convey.ShouldHaveLength, 4)
+			})
+			convey.Convey("A commit missing a tracked Benchmark is skipped", func() {
+				runs = [][]bench.Set{bisectSets("c4", map[string][]float64{"Sleep": stable})}
+				verdict, err := bisector.judge(context.Background(), "c4")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(verdict, convey.ShouldEqual, runner.VerdictSkip)
+			})
+			convey.Convey("A commit which can not be benchmarked is skipped", func() {
+				runErr = errors.New("build failed")
+				verdict, err := bisector.judge(context.Background(), "c5")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(verdict, convey.ShouldEqual, runner.VerdictSkip)
+			})
+			convey.Convey("Judging stops once context is canceled", func() {
+				runErr = context.Canceled
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := bisector.judge(ctx, "c5")
+				convey.So(err, convey.ShouldEqual, context.Canceled)
+			})
+		})
+	})
+}
+
+func TestBisectorZeroSamples(t *testing.T) {
+	convey.Convey("Given a Benchmark going from 0 to 1 allocs/op", t, func() {
+		allocSets := func(commit string, allocsPerOp float64) []bench.Set {
+			set := bench.Set{Pkg: "demo", Commit: commit, Targets: make(map[string]bench.BenchmarkList)}
+			for idx := 0; idx < 6; idx++ {
+				set.Targets["Fib"] = append(set.Targets["Fib"], bench.Benchmark{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", Runs: 1000,
+					NsPerOp: 100, Mem: bench.Mem{AllocsPerOp: allocsPerOp, Benchmem: true}})
+			}
+			return []bench.Set{set}
+		}
+		runs := [][]bench.Set{allocSets("c0", 0), allocSets("c9", 1), allocSets("c5", 0)}
+		bisector := &bisector{
+			run: func(ctx context.Context) ([]bench.Set, error) {
+				sets := runs[0]
+				runs = runs[1:]
+				return sets, nil
+			},
+			unit:  "allocs/op",
+			steps: make(map[history.Key][]history.Point),
+		}
+		fib := history.Key{Pkg: "demo", Target: "Fib", Scenario: "10"}
+
+		convey.Convey("Zero samples are measured, the regression is tracked and judged", func() {
+			var err error
+			bisector.good, err = bisector.measure(context.Background(), "good")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(bisector.good[fib], convey.ShouldResemble, []float64{0, 0, 0, 0, 0, 0})
+			badSamples, err := bisector.measure(context.Background(), "bad")
+			convey.So(err, convey.ShouldBeNil)
+			bisector.track(badSamples)
+			convey.So(bisector.tracked, convey.ShouldResemble, []history.Key{fib})
+
+			verdict, err := bisector.judge(context.Background(), "c5")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(verdict, convey.ShouldEqual, runner.VerdictGood)
+		})
+
+		convey.Convey("Runs without -benchmem do not report allocs/op", func() {
+			sets := allocSets("c0", 0)
+			for idx := range sets[0].Targets["Fib"] {
+				sets[0].Targets["Fib"][idx].Mem.Benchmem = false
+			}
+			convey.So(sampleSets(sets, "allocs/op"), convey.ShouldBeEmpty)
+		})
+	})
+}
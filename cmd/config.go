@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/config"
+	"github.com/charmbracelet/log"
+	"github.com/dlclark/regexp2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configPath = new(string)
+	profile    = new(string)
+	nameRules  = make([]bench.NameRule, 0)
+	ruleSpecs  = make([]string, 0)
+)
+
+// exclusiveFlags flags marked mutually exclusive, a config value is skipped when one of its partners is given on the command line
+var exclusiveFlags = map[string][]string{
+	"sep":     {"regex", "auto"},
+	"regex":   {"sep", "auto"},
+	"auto":    {"sep", "regex"},
+	"json":    {"format"},
+	"format":  {"json"},
+	"silent":  {"verbose"},
+	"verbose": {"silent"},
+}
+
+// partnerChanged whether any of the exclusive partners is given on the command line
+func partnerChanged(flags *pflag.FlagSet, partners []string) bool {
+	for _, partner := range partners {
+		if flags.Changed(partner) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadConfig load the config file(--config, or .benchvisual.yaml discovered from the working directory upward),
+// apply its values to flags not given on the command line and compile its name rules after the ones of --rule
+//
+//	@param cmd *cobra.Command command being executed
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 23:24:50
+func loadConfig(cmd *cobra.Command) (err error) {
+	// rules on the command line are tried before the ones in config file
+	nameRules = nameRules[:0]
+	for _, spec := range ruleSpecs {
+		confRule, err := parseRuleSpec(spec)
+		if err != nil {
+			return err
+		}
+		rule, err := compileRule(confRule)
+		if err != nil {
+			return fmt.Errorf("invalid --rule %q: %w", spec, err)
+		}
+		nameRules = append(nameRules, rule)
+	}
+
+	path := *configPath
+	if path == "" {
+		if path, err = config.Find("."); err != nil {
+			return err
+		}
+		if path == "" {
+			if *profile != "" {
+				return fmt.Errorf("--profile %s is given but no %s is found", *profile, config.FileName)
+			}
+			return nil
+		}
+	}
+	conf, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	options, err := conf.Resolve(*profile)
+	if err != nil {
+		return err
+	}
+	log.Debug("config loaded", "path", path, "profile", *profile)
+
+	flags := cmd.Flags()
+	for name, node := range options.Flags {
+		flag := flags.Lookup(name)
+		if flag == nil || name == "config" || name == "profile" {
+			if cmd.Root().Flags().Lookup(name) == nil {
+				log.Warn("unknown flag in config file", "flag", name, "path", path)
+			}
+			continue
+		}
+		// command line flags take precedence
+		if flag.Changed {
+			continue
+		}
+		if partnerChanged(flags, exclusiveFlags[name]) {
+			continue
+		}
+		// list flags take each item as a value, so that items can contain ','
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok && node.Kind == yaml.SequenceNode {
+			values, err := config.FlagValues(node)
+			if err != nil {
+				return fmt.Errorf("error when read flag %s in %s: %w", name, path, err)
+			}
+			if err = sliceValue.Replace(values); err != nil {
+				return fmt.Errorf("invalid value %q of flag %s in %s: %w", values, name, path, err)
+			}
+			continue
+		}
+		value, err := config.FlagValue(node)
+		if err != nil {
+			return fmt.Errorf("error when read flag %s in %s: %w", name, path, err)
+		}
+		// set the value without marking the flag as changed, so that flag groups only check the command line
+		if err = flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid value %q of flag %s in %s: %w", value, name, path, err)
+		}
+	}
+
+	for idx, confRule := range options.Rules {
+		rule, err := compileRule(confRule)
+		if err != nil {
+			return fmt.Errorf("invalid rule %d in %s: %w", idx, path, err)
+		}
+		nameRules = append(nameRules, rule)
+	}
+	return
+}
+
+// parseRuleSpec parse a name rule given by --rule, in the form '[pkg=<package regexp>;]sep=<separator>'
+// or '[pkg=<package regexp>;]regex=<regexp>', e.g. 'pkg=.*/fib$;sep=_'
+func parseRuleSpec(spec string) (rule config.NameRule, err error) {
+	rest := spec
+	if strings.HasPrefix(rest, "pkg=") {
+		pkg, after, found := strings.Cut(strings.TrimPrefix(rest, "pkg="), ";")
+		if !found {
+			return rule, fmt.Errorf("--rule %q should have a sep or a regex after the package pattern, e.g. 'pkg=.*/fib$;sep=_'", spec)
+		}
+		rule.Pkg, rest = pkg, after
+	}
+	key, value, _ := strings.Cut(rest, "=")
+	switch key {
+	case "sep":
+		rule.Sep = value
+	case "regex":
+		rule.Regex = value
+	default:
+		return rule, fmt.Errorf("--rule %q should be in the form '[pkg=<package regexp>;]sep=<separator>' or '[pkg=<package regexp>;]regex=<regexp>'", spec)
+	}
+	return
+}
+
+// compileRule compile a name rule of config file or --rule
+func compileRule(confRule config.NameRule) (rule bench.NameRule, err error) {
+	if confRule.Sep == "" && confRule.Regex == "" {
+		return rule, fmt.Errorf("rule should have a sep or a regex")
+	}
+	rule.Sep = confRule.Sep
+	if confRule.Pkg != "" {
+		if rule.Pkg, err = regexp.Compile(confRule.Pkg); err != nil {
+			return rule, fmt.Errorf("invalid package pattern %q: %w", confRule.Pkg, err)
+		}
+	}
+	if confRule.Sep == "" {
+		if rule.Regex, err = regexp2.Compile(confRule.Regex, 0); err != nil {
+			return rule, fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+	return
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/config"
+	"github.com/smartystreets/goconvey/convey"
+	"github.com/spf13/cobra"
+)
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	convey.Convey("Given a config file and a command with flags", t, func() {
+		path := filepath.Join(t.TempDir(), ".benchvisual.yaml")
+		content := "output: ./from-config\nformat: junit\nregex: '^Bench(?<target>\\w+)$'\nbaseline: [1, 2, 3]\n"
+		convey.So(os.WriteFile(path, []byte(content), 0o644), convey.ShouldBeNil)
+		defaultConfigPath := *configPath
+		*configPath = path
+		defer func() { *configPath, nameRules = defaultConfigPath, nameRules[:0] }()
+
+		var (
+			output, format, sepValue, regexValue string
+			baselineValues                       []float64
+		)
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().StringVar(&output, "output", "./benchmark", "")
+		cmd.Flags().StringVar(&format, "format", "html", "")
+		cmd.Flags().StringVar(&sepValue, "sep", "", "")
+		cmd.Flags().StringVar(&regexValue, "regex", "", "")
+		cmd.Flags().Float64SliceVar(&baselineValues, "baseline", nil, "")
+
+		convey.Convey("Flags on the command line take precedence over the config file", func() {
+			convey.So(cmd.Flags().Parse([]string{"--format", "csv", "--sep", "_"}), convey.ShouldBeNil)
+			convey.So(loadConfig(cmd), convey.ShouldBeNil)
+			convey.So(format, convey.ShouldEqual, "csv")
+			convey.So(sepValue, convey.ShouldEqual, "_")
+			// exclusive with --sep given on the command line
+			convey.So(regexValue, convey.ShouldEqual, "")
+			convey.So(output, convey.ShouldEqual, "./from-config")
+			convey.So(baselineValues, convey.ShouldResemble, []float64{1, 2, 3})
+			convey.So(cmd.Flags().Changed("output"), convey.ShouldBeFalse)
+		})
+		convey.Convey("Rules of --rule are tried before rules in the config file", func() {
+			defaultRuleSpecs := ruleSpecs
+			ruleSpecs = []string{"pkg=.*/fib$;sep=_", "regex=^Bench(?<target>\\w+)/(?<scenario>\\w+)$"}
+			defer func() { ruleSpecs = defaultRuleSpecs }()
+			convey.So(os.WriteFile(path, []byte("rules:\n  - sep: \"-\"\n"), 0o644), convey.ShouldBeNil)
+			convey.So(cmd.Flags().Parse(nil), convey.ShouldBeNil)
+			convey.So(loadConfig(cmd), convey.ShouldBeNil)
+			convey.So(nameRules, convey.ShouldHaveLength, 3)
+			convey.So(nameRules[0].Pkg.String(), convey.ShouldEqual, ".*/fib$")
+			convey.So(nameRules[0].Sep, convey.ShouldEqual, "_")
+			convey.So(nameRules[1].Regex.String(), convey.ShouldEqual, `^Bench(?<target>\w+)/(?<scenario>\w+)$`)
+			convey.So(nameRules[2].Sep, convey.ShouldEqual, "-")
+		})
+		convey.Convey("Values of the config file are used when flags are not given", func() {
+			convey.So(cmd.Flags().Parse(nil), convey.ShouldBeNil)
+			convey.So(loadConfig(cmd), convey.ShouldBeNil)
+			convey.So(format, convey.ShouldEqual, "junit")
+			convey.So(regexValue, convey.ShouldEqual, `^Bench(?<target>\w+)$`)
+		})
+	})
+}
+
+func TestParseRuleSpec(t *testing.T) {
+	convey.Convey("Parse name rules given by --rule", t, func() {
+		rule, err := parseRuleSpec("sep=_")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(rule, convey.ShouldResemble, config.NameRule{Sep: "_"})
+		rule, err = parseRuleSpec("pkg=.*/fib$;regex=^Bench(?<target>\\w+);(?<scenario>\\w+)$")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(rule, convey.ShouldResemble, config.NameRule{Pkg: ".*/fib$", Regex: `^Bench(?<target>\w+);(?<scenario>\w+)$`})
+		_, err = parseRuleSpec("pkg=.*/fib$")
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parseRuleSpec("_")
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
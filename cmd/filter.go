@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+)
+
+var (
+	includePkgs      = make([]string, 0)
+	excludePkgs      = make([]string, 0)
+	includeTargets   = make([]string, 0)
+	excludeTargets   = make([]string, 0)
+	includeScenarios = make([]string, 0)
+	excludeScenarios = make([]string, 0)
+	includeNames     = make([]string, 0)
+	excludeNames     = make([]string, 0)
+	includeDims      = make([]string, 0)
+	excludeDims      = make([]string, 0)
+
+	benchFilter = new(bench.Filter)
+)
+
+// compileFilter compile include / exclude patterns given by flags into benchFilter
+//
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:05:12
+func compileFilter() (err error) {
+	matchers := []struct {
+		matcher          *bench.Matcher
+		include, exclude []string
+	}{
+		{&benchFilter.Pkg, includePkgs, excludePkgs},
+		{&benchFilter.Target, includeTargets, excludeTargets},
+		{&benchFilter.Scenario, includeScenarios, excludeScenarios},
+		{&benchFilter.Name, includeNames, excludeNames},
+	}
+	for _, m := range matchers {
+		if m.matcher.Include, err = compilePatterns(m.include); err != nil {
+			return err
+		}
+		if m.matcher.Exclude, err = compilePatterns(m.exclude); err != nil {
+			return err
+		}
+	}
+
+	benchFilter.Dimensions = make(map[string]bench.Matcher)
+	for _, m := range []struct {
+		exprs   []string
+		exclude bool
+	}{{includeDims, false}, {excludeDims, true}} {
+		for _, expr := range m.exprs {
+			dimension, pattern, found := strings.Cut(expr, "=")
+			if !found || dimension == "" {
+				return fmt.Errorf("invalid dimension filter %q, it should be <dimension>=<regexp>", expr)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+			}
+			matcher := benchFilter.Dimensions[dimension]
+			if m.exclude {
+				matcher.Exclude = append(matcher.Exclude, re)
+			} else {
+				matcher.Include = append(matcher.Include, re)
+			}
+			benchFilter.Dimensions[dimension] = matcher
+		}
+	}
+	return
+}
+
+func compilePatterns(patterns []string) (compiled []*regexp.Regexp, err error) {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringArrayVar(&includePkgs, "pkg", []string{}, "only keep Benchmark of packages matching the regexp, can be given multiple times")
+	flags.StringArrayVar(&excludePkgs, "exclude-pkg", []string{}, "drop Benchmark of packages matching the regexp, can be given multiple times")
+	flags.StringArrayVar(&includeTargets, "include-target", []string{}, "only keep Benchmark whose target matches the regexp, can be given multiple times")
+	flags.StringArrayVar(&excludeTargets, "exclude-target", []string{}, "drop Benchmark whose target matches the regexp, can be given multiple times")
+	flags.StringArrayVar(&includeScenarios, "include-scenario", []string{}, "only keep Benchmark whose scenario matches the regexp, can be given multiple times")
+	flags.StringArrayVar(&excludeScenarios, "exclude-scenario", []string{}, "drop Benchmark whose scenario matches the regexp, can be given multiple times")
+	flags.StringArrayVar(&includeNames, "name", []string{}, "only keep Benchmark whose full name(e.g. BenchmarkFibonacci/100times) matches the regexp, can be given multiple times")
+	flags.StringArrayVar(&excludeNames, "exclude-name", []string{}, "drop Benchmark whose full name matches the regexp, can be given multiple times")
+	flags.StringArrayVar(&includeDims, "match", []string{}, "only keep Benchmark whose dimension(a label or a key=value parameter of its name) matches, in the form <dimension>=<regexp>, e.g. 'size=^1024$', can be given multiple times")
+	flags.StringArrayVar(&excludeDims, "exclude-match", []string{}, "drop Benchmark whose dimension matches, in the form <dimension>=<regexp>, can be given multiple times")
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/history"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changePointOptions = history.DefaultChangePointOptions
+	failOnRegression   = new(bool)
+)
+
+// historyCmd render trends of Benchmark over runs stored in the history file
+var historyCmd = &cobra.Command{
+	Use:   "history --history <history path> [-o <output path>]",
+	Short: "Render trend charts of Benchmark over runs stored by --history",
+	Long: `Render trend charts of Benchmark over runs stored by --history.
+every run parsed with --history <path> is appended to the JSON-lines history file, with its timestamp and git commit,
+this command reads the file back and renders a line chart for every metric of every Benchmark(keyed by package, target and scenario),
+one page per package(history-<pkg>.html), filters work as well.
+statistically significant shifts of every metric are detected(binary segmentation by Welch's t-test of the runs on both sides),
+a shift needs at least --min-runs runs on each side, so a single noisy run is never reported,
+every change is logged with its first bad commit, the magnitude and the confidence, and marked on the trend charts,
+add --fail-on-regression to exit with error when a regression is detected.`,
+	Example: `  go test ./... -run '^$' -bench . -benchmem | benchvisual -s / --history bench_history.jsonl
+  benchvisual history --history bench_history.jsonl -o ./report --include-target 'Pond-.*'
+  benchvisual history --history bench_history.jsonl --confidence 0.999 --min-change 0.05 --fail-on-regression`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if _, err = prepare(); err != nil {
+			return err
+		}
+		if *historyPath == "" {
+			return fmt.Errorf("--history is required to read the history file")
+		}
+		entries, err := history.Load(*historyPath)
+		if err != nil {
+			return err
+		}
+		for idx := range entries {
+			entries[idx].Sets = benchFilter.Apply(entries[idx].Sets)
+		}
+		trends := history.Trends(entries)
+		log.Info("Benchmark history loaded", "run_num", len(entries), "benchmark_num", len(trends))
+
+		changes := history.ChangePoints(trends, changePointOptions)
+		regressions := 0
+		for _, change := range changes {
+			if change.Regression {
+				regressions++
+				log.Warn("regression detected: " + change.String())
+			} else {
+				log.Info("improvement detected: " + change.String())
+			}
+		}
+
+		savedPaths, err := visual.VisualizeHistory(*outputDir, trends, changes)
+		if err != nil {
+			return err
+		}
+		log.Info("Benchmark history visualized success", "saved paths", savedPaths)
+		if *failOnRegression && regressions > 0 {
+			return fmt.Errorf("%d regression(s) detected in Benchmark history", regressions)
+		}
+		return nil
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// appendHistory append a run of Benchmark sets to the history file, the git commit of the working directory
+// is recorded when the sets do not carry one(pipe mode and file mode)
+//
+//	@param ctx context.Context
+//	@param path string
+//	@param sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+func appendHistory(ctx context.Context, path string, sets []bench.Set) (err error) {
+	timestamp, err := parseTimestamp(*timestampStr)
+	if err != nil {
+		return err
+	}
+	entry := history.NewEntry(sets, timestamp)
+	if entry.Commit == "" {
+		if entry.Commit, err = runner.GitCommit(ctx, "."); err != nil {
+			log.Debug("no git commit recorded in history", "err", err)
+		}
+	}
+	if err = history.Append(path, entry); err != nil {
+		return err
+	}
+	log.Info("Benchmark run appended to history", "path", path, "commit", entry.Commit)
+	return nil
+}
+
+func init() {
+	historyCmd.Flags().Float64Var(&changePointOptions.Confidence, "confidence", changePointOptions.Confidence, "minimal confidence of a shift to be reported as a change, e.g. 0.99")
+	historyCmd.Flags().IntVar(&changePointOptions.MinRuns, "min-runs", changePointOptions.MinRuns, "minimal runs on each side of a change")
+	historyCmd.Flags().Float64Var(&changePointOptions.MinChange, "min-change", changePointOptions.MinChange, "minimal relative change of the mean to be reported, e.g. 0.02 for 2%")
+	historyCmd.Flags().IntVar(&changePointOptions.Window, "window", changePointOptions.Window, "maximal runs on each side of a candidate change compared by the t-test, 0 for all runs")
+	historyCmd.Flags().BoolVar(failOnRegression, "fail-on-regression", false, "exit with error when a regression is detected")
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/export"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	"github.com/charmbracelet/log"
+)
+
+// output formats supported by --format
+const (
+	formatHTML        = "html"
+	formatJSON        = "json"
+	formatCSV         = "csv"
+	formatTSV         = "tsv"
+	formatBenchfmt    = "benchfmt"
+	formatJUnit       = "junit"
+	formatOpenMetrics = "openmetrics"
+	formatInflux      = "influx"
+)
+
+var outputFormats = []string{formatHTML, formatJSON, formatCSV, formatTSV, formatBenchfmt, formatJUnit, formatOpenMetrics, formatInflux}
+
+// writeOutput export parsed Benchmark sets into outputDir in the given format
+//
+//	@param ctx context.Context
+//	@param format string
+//	@param outputDir string
+//	@param sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 10:20:05
+func writeOutput(ctx context.Context, format string, outputDir string, sets []bench.Set) (err error) {
+	var (
+		buffer   = new(bytes.Buffer)
+		fileName string
+	)
+	switch format {
+	case formatHTML:
+		savedPath, err := visual.Visualize(outputDir, sets, layout)
+		if err != nil {
+			return err
+		}
+		log.Info("Benchmark visualized success", "saved paths", savedPath)
+		return nil
+	case formatJSON:
+		// json mode, only export parsed Benchmark in json file
+		err = bench.WriteJSON(buffer, sets)
+		fileName = "parsed_benchmark.json"
+	case formatCSV:
+		err = export.CSV(buffer, sets, ',')
+		fileName = "parsed_benchmark.csv"
+	case formatTSV:
+		err = export.CSV(buffer, sets, '\t')
+		fileName = "parsed_benchmark.tsv"
+	case formatBenchfmt:
+		// standard 'go test -bench' text, for benchstat and friends
+		err = bench.Write(buffer, sets)
+		fileName = "parsed_benchmark.txt"
+	case formatJUnit:
+		// every Benchmark as a testcase, missing baseline as failure
+		err = export.JUnit(buffer, sets)
+		fileName = "parsed_benchmark.junit.xml"
+	case formatOpenMetrics:
+		// '.prom' suffix is required by node_exporter textfile collector
+		err = export.OpenMetrics(buffer, sets, len(baselines) > 0)
+		fileName = "parsed_benchmark.prom"
+	case formatInflux:
+		var timestamp time.Time
+		if timestamp, err = parseTimestamp(*timestampStr); err != nil {
+			return err
+		}
+		err = export.Influx(buffer, sets, export.DefaultMeasurement, timestamp)
+		fileName = "parsed_benchmark.lp"
+	default:
+		return fmt.Errorf("unsupported output format %q, should be one of [%s]", format, strings.Join(outputFormats, ", "))
+	}
+	if err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(outputDir, fileName)
+	if err = os.WriteFile(outputPath, buffer.Bytes(), os.ModePerm); err != nil {
+		return err
+	}
+	log.Info("parsed Benchmark exported success", "format", format, "saved path", outputPath)
+
+	if format == formatInflux && *pushURL != "" {
+		token := *pushToken
+		if token == "" {
+			token = os.Getenv("INFLUX_TOKEN")
+		}
+		if err = export.PushInflux(ctx, *pushURL, token, buffer.Bytes()); err != nil {
+			return err
+		}
+		log.Info("parsed Benchmark pushed success", "push url", *pushURL)
+	}
+	return nil
+}
+
+// parseTimestamp parse timestamp in RFC3339 or unix seconds, empty string means not configured(zero time)
+//
+//	@param timestampStr string
+//	@return timestamp time.Time
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 12:18:20
+func parseTimestamp(timestampStr string) (timestamp time.Time, err error) {
+	if timestampStr == "" {
+		return
+	}
+	if seconds, err := strconv.ParseInt(timestampStr, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	if timestamp, err = time.Parse(time.RFC3339, timestampStr); err != nil {
+		return timestamp, fmt.Errorf("invalid timestamp %q, should be RFC3339 or unix seconds: %w", timestampStr, err)
+	}
+	return
+}
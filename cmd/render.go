@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+// renderCmd render Benchmark sets exported in json
+var renderCmd = &cobra.Command{
+	Use:   "render <parsed_benchmark.json>... [-o <output path>] [--format <format>]",
+	Short: "Render Benchmark sets previously exported by --format json",
+	Long: `Render Benchmark sets previously exported by --format json.
+benchvisual loads the json files(use '-' for stdin), merges sets of all of them in order, and renders them like parsed Benchmark output,
+so an export can be edited or merged and rendered again, filters, baseline and --format work as well.
+outliers flagged(or dropped) at export are kept as they are, and rendered sets are never appended to --history.
+json exported by older versions of benchvisual keeps loading, the schema is versioned by 'schema_version' of each set.`,
+	Example: `  benchvisual render parsed_benchmark.json
+  benchvisual render before/parsed_benchmark.json after/parsed_benchmark.json --include-target 'Pond-.*'
+  jq '...' parsed_benchmark.json | benchvisual render - --format csv`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if _, err = prepare(); err != nil {
+			return err
+		}
+
+		var sets []bench.Set
+		for _, path := range args {
+			loaded, err := loadJSON(path)
+			if err != nil {
+				return err
+			}
+			log.Info("Benchmark sets loaded", "path", path, "set_num", len(loaded))
+			sets = append(sets, loaded...)
+		}
+		// outliers were detected when the sets were exported, and the sets are not a new run to record
+		if sets, err = refine(sets, false); err != nil {
+			return err
+		}
+		return emit(cmd.Context(), sets, false)
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// loadJSON load Benchmark sets from a json file, '-' means stdin
+//
+//	@param path string
+//	@return sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:26:37
+func loadJSON(path string) (sets []bench.Set, err error) {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		reader = f
+	}
+	if sets, err = bench.ReadJSON(reader); err != nil {
+		return nil, fmt.Errorf("error when load %s: %w", path, err)
+	}
+	return
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+}
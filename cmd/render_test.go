@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestRenderLoaded(t *testing.T) {
+	convey.Convey("Given exported Benchmark sets with an outlier run already dropped", t, func() {
+		defaultMethod, defaultDrop, defaultHistory, defaultFormat, defaultOutput := *outlierMethod, *dropOutliers, *historyPath, *format, *outputDir
+		defer func() {
+			*outlierMethod, *dropOutliers, *historyPath, *format, *outputDir = defaultMethod, defaultDrop, defaultHistory, defaultFormat, defaultOutput
+		}()
+		*outlierMethod, *dropOutliers, *format, *outputDir = bench.OutlierIQR, true, "json", t.TempDir()
+		*historyPath = filepath.Join(t.TempDir(), "history.jsonl")
+
+		var runs bench.BenchmarkList
+		for _, nsPerOp := range []float64{100, 101, 99, 102, 100, 98, 130} {
+			runs = append(runs, bench.Benchmark{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", Runs: 1000, NsPerOp: nsPerOp})
+		}
+		sets := []bench.Set{{Pkg: "demo", OutliersDropped: 1, Targets: map[string]bench.BenchmarkList{"Fib": runs}}}
+
+		convey.Convey("Outliers are not dropped again and the sets are not recorded in history", func() {
+			refined, err := refine(sets, false)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(refined[0].Targets["Fib"], convey.ShouldHaveLength, 7)
+			convey.So(refined[0].OutliersDropped, convey.ShouldEqual, 1)
+
+			convey.So(emit(context.Background(), refined, false), convey.ShouldBeNil)
+			_, err = os.Stat(*historyPath)
+			convey.So(os.IsNotExist(err), convey.ShouldBeTrue)
+		})
+
+		convey.Convey("While parsed sets are", func() {
+			refined, err := refine(sets, true)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(refined[0].Targets["Fib"], convey.ShouldHaveLength, 6)
+			convey.So(refined[0].OutliersDropped, convey.ShouldEqual, 2)
+
+			convey.So(emit(context.Background(), refined, true), convey.ShouldBeNil)
+			_, err = os.Stat(*historyPath)
+			convey.So(err, convey.ShouldBeNil)
+		})
+	})
+}
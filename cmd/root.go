@@ -18,36 +18,55 @@ package cmd
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
-	"path/filepath"
+	"os/exec"
+	"strconv"
+	"strings"
 
 	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/collections"
 	"github.com/Kevinello/benchvisual/internal/visual"
 	"github.com/charmbracelet/log"
 	"github.com/dlclark/regexp2"
-	jsoniter "github.com/json-iterator/go"
 	"github.com/spf13/cobra"
 )
 
 var (
-	json = jsoniter.ConfigCompatibleWithStandardLibrary
-
-	sep       = new(string)
-	regexStr  = new(string)
-	filePath  = new(string)
-	outputDir = new(string)
-	jsonMode  = new(bool)
-	silent    = new(bool)
-	verbose   = new(bool)
-	baselines = make([]float64, 0)
+	sep           = new(string)
+	regexStr      = new(string)
+	filePath      = new(string)
+	teePath       = new(string)
+	outputDir     = new(string)
+	jsonMode      = new(bool)
+	format        = new(string)
+	timestampStr  = new(string)
+	pushURL       = new(string)
+	pushToken     = new(string)
+	catchAll      = new(bool)
+	autoSplit     = new(bool)
+	silent        = new(bool)
+	verbose       = new(bool)
+	historyPath   = new(string)
+	outlierMethod = new(string)
+	dropOutliers  = new(bool)
+	noiseOptions  = bench.DefaultNoiseOptions
+	rankWeightStr = make(map[string]string)
+	rankWeights   = bench.DefaultRankWeights
+	baselines     = make([]float64, 0)
+
+	layout = visual.DefaultLayout
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use: "benchvisual [--version] [--help] [-s <separator> | -r <regexp>] [-f <benchmark path>] [-o <output path>] [--json] [--verbose / --silent] [--baseline <baseline>...]",
+	Use: "benchvisual [--version] [--help] [-s <separator> | -r <regexp> | --auto] [-f <benchmark path>] [--tee[=<path>]] [-o <output path>] [--json | --format <format>] [--verbose / --silent] [--baseline <baseline>...]",
 	Example: `  go test -bench . | benchvisual -r '^Bench(mark)?(?<target>\\S+)/(?<scenario>\\S+)$'
+  go test -bench . | benchvisual -s '/' --tee | tee log.txt
+  go test -bench . | benchvisual --auto
   benchvisual -s '/' -f "path/to/origin/benchmark/file"`,
 	Short: "Parse and visualize Golang standard Benchmark output",
 	Long: `Parse and visualize Golang standard Benchmark output.
@@ -59,86 +78,325 @@ In the visualization progress, we will visualize Benchmark in a concepts mapping
 	- metrics(ns/op...)  -> series of metrics value
 	- targets            -> series name(x axis)
 	- scenarios          -> dummy values in charts(group name)
+other named groups of --regex are kept as labels of Benchmark, use --series, --x-axis and --split-by to choose
+which of target, scenario and labels drives the series, the x axis and the split of charts.
+repeated samples of a Benchmark(e.g. by -count) are averaged in bars, add --error-bars and --box-plot to show their spread.
+add --reference-target to compare every target with a reference target(e.g. the standard library) in ratio(or --speedup) charts.
+targets are ranked in every scenario and metric, and overall by geometric mean of ratios to the best(weighted by --rank-weights),
+rankings are listed under the charts and kept in json.
+Benchmark can be filtered by package, target, scenario and name with --pkg, --include-target, --exclude-scenario, --name... before output.
+outlier runs of a Benchmark(e.g. by -count) are detected by --outliers(iqr in default), flagged in json and charts, and removed with --drop-outliers.
+statistically weak Benchmark(few iterations by --min-iterations, high variance by --max-cv, few runs by --min-samples) are warned in json,
+badged on charts and listed in a summary section of the page.
 benchvisual also provides json output format for your secondary development, use --json to let it output json file.
-benchvisual also provides baseline feature, use --baseline to let it calculate baseline for each Benchmark.`,
+benchvisual also provides flat csv / tsv output for spreadsheets and dataframes, use --format csv or --format tsv.
+benchvisual can also write the parsed Benchmark back to standard Benchmark output, use --format benchfmt.
+benchvisual can also export OpenMetrics gauges for node_exporter textfile collector, use --format openmetrics.
+benchvisual can also export InfluxDB line protocol and push it to InfluxDB v2, use --format influx [--push-url <write endpoint>].
+benchvisual also provides baseline feature, use --baseline to let it calculate baseline for each Benchmark,
+combine it with --format junit to get a JUnit XML report for CI, where Benchmark missing its baseline is reported as a failure.
+benchvisual can also append every run to a history file with --history, and render trends over runs with 'benchvisual history'.
+benchvisual can also find the commit introducing a Benchmark regression with 'benchvisual bisect',
+and compare two revisions by running their Benchmark alternately with 'benchvisual ab'.
+flags can also be set in a .benchvisual.yaml config file discovered from the working directory upward(or given by --config),
+with named profiles selected by --profile and ordered name rules(optionally scoped by package, also given by --rule) tried before --sep / --regex,
+flags on the command line take precedence.`,
 	Version: "0.2.1",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		var regex *regexp2.Regexp
-		if *sep == "" {
-			// only parse regexp when sep is empty
-			regex, err = regexp2.Compile(*regexStr, 0)
-			if err != nil {
-				return
-			}
-		}
-		// check if the output path is exist
-		if fileInfo, err := os.Stat(*outputDir); os.IsNotExist(err) {
-			return fmt.Errorf("given output directory path not exist: %s", *outputDir)
-		} else if err != nil {
-			return fmt.Errorf("error when stat given output directory path: %s", *outputDir)
-		} else if !fileInfo.IsDir() {
-			return fmt.Errorf("given path is not a directory: %s", *outputDir)
-		}
-
-		if *silent {
-			log.SetLevel(log.FatalLevel)
-		} else if *verbose {
-			log.SetLevel(log.DebugLevel)
+		regex, err := prepare()
+		if err != nil {
+			return err
 		}
 
-		var reader *bufio.Reader
+		var input io.Reader
 		if *filePath != "" {
 			// file mode
 			f, err := os.Open(*filePath)
 			if err != nil {
 				return err
 			}
-			reader = bufio.NewReader(f)
+			defer f.Close()
+			input = f
 		} else {
 			// pipe mode
-			reader = bufio.NewReader(os.Stdin)
+			input = os.Stdin
 		}
 
-		sets, err := bench.Parse(reader, *sep, regex)
+		sets, err := parseInput(input, *teePath, regex)
 		if err != nil {
 			return err
 		}
 		log.Info("Benchmark parsed success", "set_num", len(sets))
 
-		if len(baselines) > 0 {
-			if len(baselines) != 3 {
-				return fmt.Errorf("baseline should be a 3 elements array, got %v", baselines)
-			}
-			bench.Baseline(sets, baselines)
-			log.Info("Benchmark baseline success")
+		return report(cmd.Context(), sets)
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// prepare validate flags shared by root command and its sub commands, setup logger
+// and compile the regexp of Benchmark name
+//
+//	@return regex *regexp2.Regexp nil when separator is given
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 12:40:11
+func prepare() (regex *regexp2.Regexp, err error) {
+	if *sep == "" {
+		// only parse regexp when sep is empty
+		regex, err = regexp2.Compile(*regexStr, 0)
+		if err != nil {
+			return
 		}
+	}
+	// check if the output path is exist
+	if fileInfo, err := os.Stat(*outputDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("given output directory path not exist: %s", *outputDir)
+	} else if err != nil {
+		return nil, fmt.Errorf("error when stat given output directory path: %s", *outputDir)
+	} else if !fileInfo.IsDir() {
+		return nil, fmt.Errorf("given path is not a directory: %s", *outputDir)
+	}
+	if layout.Series == "" || layout.XAxis == "" {
+		return nil, fmt.Errorf("--series and --x-axis should not be empty")
+	}
+	if layout.Series == layout.XAxis || layout.Split != "" && (layout.Split == layout.Series || layout.Split == layout.XAxis) {
+		return nil, fmt.Errorf("--series, --x-axis and --split-by should be different dimensions, got %q, %q and %q", layout.Series, layout.XAxis, layout.Split)
+	}
+	if len(baselines) > 0 && len(baselines) != 3 {
+		return nil, fmt.Errorf("baseline should be a 3 elements array, got %v", baselines)
+	}
+	if *pushURL != "" && *format != formatInflux {
+		return nil, fmt.Errorf("--push-url only works with --format %s", formatInflux)
+	}
+	if layout.ErrorBars != "" && !collections.Contains(visual.ErrorBarModes, layout.ErrorBars) {
+		return nil, fmt.Errorf("--error-bars should be one of [%s], got %q", strings.Join(visual.ErrorBarModes, ", "), layout.ErrorBars)
+	}
+	if layout.Reference != "" && !collections.Contains([]string{layout.Series, layout.XAxis, layout.Split}, "target") {
+		return nil, fmt.Errorf("--reference-target needs target as one of --series, --x-axis and --split-by")
+	}
+	if layout.Speedup && layout.Reference == "" {
+		return nil, fmt.Errorf("--speedup only works with --reference-target")
+	}
+	if len(rankWeightStr) > 0 {
+		if rankWeights, err = parseRankWeights(rankWeightStr); err != nil {
+			return nil, err
+		}
+	}
+	if !collections.Contains(bench.OutlierMethods, *outlierMethod) {
+		return nil, fmt.Errorf("--outliers should be one of [%s], got %q", strings.Join(bench.OutlierMethods, ", "), *outlierMethod)
+	}
+	if err = compileFilter(); err != nil {
+		return nil, err
+	}
+	if *jsonMode {
+		// --json is kept as a shortcut of --format json
+		*format = formatJSON
+	}
 
-		if *jsonMode {
-			// json mode, only export parsed Benchmark in json file
-			setsInBytes, err := json.MarshalIndent(sets, "", "    ")
-			if err != nil {
-				return err
-			}
-			log.Debug("marshal parsed Benchmark success")
+	if *silent {
+		log.SetLevel(log.FatalLevel)
+	} else if *verbose {
+		log.SetLevel(log.DebugLevel)
+	}
+	return
+}
 
-			outputPath := filepath.Join(*outputDir, "parsed_benchmark.json")
-			err = ioutil.WriteFile(outputPath, setsInBytes, os.ModePerm)
-			if err != nil {
-				return err
+// scanOptions options of Benchmark Scanner given by config file and flags
+//
+//	@return opts []bench.ScanOption
+//	@author kevineluo
+//	@update 2026-10-19 16:52:14
+func scanOptions() (opts []bench.ScanOption) {
+	opts = append(opts, bench.WithNameRules(nameRules...))
+	if *catchAll {
+		opts = append(opts, bench.WithCatchAll())
+	}
+	if *autoSplit {
+		opts = append(opts, bench.WithAutoSplit())
+	}
+	return
+}
+
+// report refine parsed Benchmark sets and emit them, the run is recorded in history
+//
+//	@param ctx context.Context
+//	@param sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 02:14:52
+func report(ctx context.Context, sets []bench.Set) (err error) {
+	if sets, err = refine(sets, true); err != nil {
+		return err
+	}
+	return emit(ctx, sets, true)
+}
+
+// refine filter parsed Benchmark sets, mark(and drop) outlier runs, warn on noise and check their baseline
+//
+//	@param sets []bench.Set
+//	@param detectOutliers bool whether to mark(and drop) outlier runs, false for sets whose outliers are already detected,
+//		e.g. loaded from exported json, so that outliers are never dropped twice
+//	@return refined []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 02:14:52
+func refine(sets []bench.Set, detectOutliers bool) (refined []bench.Set, err error) {
+	if sets = benchFilter.Apply(sets); len(sets) == 0 {
+		log.Warn("no Benchmark left after filtering")
+	}
+	if detectOutliers {
+		count, err := bench.MarkOutliers(sets, *outlierMethod)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 && *dropOutliers {
+			log.Info("outlier samples removed", "method", *outlierMethod, "removed", bench.DropOutliers(sets))
+		} else if count > 0 {
+			log.Info("outlier samples detected, drop them with --drop-outliers", "method", *outlierMethod, "outliers", count)
+		}
+	}
+	if weak := bench.CheckNoise(sets, noiseOptions); weak > 0 {
+		log.Warn("statistically weak Benchmark results, see warnings in output", "benchmarks", weak)
+	}
+	for _, set := range sets {
+		if layout.Reference != "" && len(set.Targets[layout.Reference]) == 0 {
+			log.Warn("reference target not found in package, no relative charts for it", "pkg", set.Pkg, "reference target", layout.Reference)
+		}
+	}
+	if len(baselines) > 0 {
+		bench.Baseline(sets, baselines)
+		log.Info("Benchmark baseline success")
+	}
+	return sets, nil
+}
+
+// checkDimensions check that every dimension of the layout is target, scenario, or a label(named group of --regex
+// or a name rule) or key=value parameter of some Benchmark, which would otherwise put every Benchmark in the same cell
+//
+//	@param sets []bench.Set
+//	@param layout visual.Layout
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 23:33:27
+func checkDimensions(sets []bench.Set, layout visual.Layout) (err error) {
+	for _, dimension := range [][2]string{{"--series", layout.Series}, {"--x-axis", layout.XAxis}, {"--split-by", layout.Split}} {
+		if name := dimension[1]; name != "" && name != "target" && name != "scenario" && !hasDimension(sets, name) {
+			return fmt.Errorf("%s %q is neither target, scenario, a named group of --regex(or a name rule) nor a key=value parameter of any Benchmark name", dimension[0], name)
+		}
+	}
+	return nil
+}
+
+// hasDimension whether any Benchmark has a label or parameter of the name
+func hasDimension(sets []bench.Set, name string) bool {
+	for _, set := range sets {
+		for _, benchmarks := range set.Targets {
+			for _, benchmark := range benchmarks {
+				if _, ok := benchmark.Labels[name]; ok {
+					return true
+				}
+				if _, ok := benchmark.Params[name]; ok {
+					return true
+				}
 			}
-			log.Info("parsed Benchmark json exported success", "saved path", outputPath)
-			return nil
 		}
-		savedPath, err := visual.Visualize(*outputDir, sets)
-		if err != nil {
+	}
+	return false
+}
+
+// emit check dimensions of the layout against refined Benchmark sets, append them to history,
+// rank their targets and write them in the output format
+//
+//	@param ctx context.Context
+//	@param sets []bench.Set
+//	@param record bool whether to append the sets to --history as a run, false for sets which are not a new run
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 02:14:52
+func emit(ctx context.Context, sets []bench.Set, record bool) (err error) {
+	if len(sets) > 0 {
+		if err = checkDimensions(sets, layout); err != nil {
+			return err
+		}
+	}
+	if record && *historyPath != "" && len(sets) > 0 {
+		if err = appendHistory(ctx, *historyPath, sets); err != nil {
 			return err
 		}
-		log.Info("Benchmark visualized success", "saved paths", savedPath)
+	}
+	// rankings are derived from Benchmark, so they are not kept in history
+	bench.Rank(sets, rankWeights)
+	return writeOutput(ctx, *format, *outputDir, sets)
+}
 
-		return nil
-	},
-	SilenceUsage:  true,
-	SilenceErrors: true,
+// parseRankWeights parse weights of metrics in the overall ranking from --rank-weights, e.g. ns/op=1
+//
+//	@param weightStr map[string]string
+//	@return weights map[string]float64
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 21:48:15
+func parseRankWeights(weightStr map[string]string) (weights map[string]float64, err error) {
+	weights = make(map[string]float64, len(weightStr))
+	positive := false
+	for unit, value := range weightStr {
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("--rank-weights should be non-negative numbers, got %s=%s", unit, value)
+		}
+		weights[unit] = weight
+		positive = positive || weight > 0
+	}
+	if !positive {
+		return nil, fmt.Errorf("--rank-weights should have a positive weight")
+	}
+	return weights, nil
+}
+
+// parseInput parse Benchmark sets from input, copying every input line unchanged to the --tee destination while parsing,
+// the rest of input is passed through even if parsing stops early, and the destination is closed before rendering
+//
+//	@param input io.Reader
+//	@param teePath string destination of --tee, no copy when empty
+//	@param regex *regexp2.Regexp
+//	@return sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 23:02:14
+func parseInput(input io.Reader, teePath string, regex *regexp2.Regexp) (sets []bench.Set, err error) {
+	if teePath != "" {
+		teeWriter, closeTee, err := openTee(teePath)
+		if err != nil {
+			return nil, err
+		}
+		defer closeTee()
+		input = io.TeeReader(input, teeWriter)
+		defer io.Copy(io.Discard, input)
+	}
+	return bench.Parse(bufio.NewReader(input), *sep, regex, scanOptions()...)
+}
+
+// openTee open the destination of --tee, '-' means stdout
+//
+//	@param path string
+//	@return writer io.Writer
+//	@return closeFn func() error
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 15:20:32
+func openTee(path string) (writer io.Writer, closeFn func() error, err error) {
+	if path == "-" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when create tee file: %w", err)
+	}
+	return f, f.Close, nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -146,22 +404,56 @@ benchvisual also provides baseline feature, use --baseline to let it calculate b
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
+		// keep exit status of the wrapped command(e.g. go test in run mode)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			log.Error(err)
+			os.Exit(exitErr.ExitCode())
+		}
 		log.Fatal(err)
 		os.Exit(1)
 	}
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(filePath, "file", "f", "", "use file mode instead of pipe mode, Read the original Benchmark output from the given file path")
+	rootCmd.Flags().StringVarP(filePath, "file", "f", "", "use file mode instead of pipe mode, Read the original Benchmark output from the given file path")
+	rootCmd.Flags().StringVar(teePath, "tee", "", "copy every input line unchanged to stdout(--tee) or the given file path(--tee=<path>) while parsing")
+	rootCmd.Flags().Lookup("tee").NoOptDefVal = "-"
+	rootCmd.PersistentFlags().StringVar(configPath, "config", "", "config file path (default .benchvisual.yaml discovered from the working directory upward)")
+	rootCmd.PersistentFlags().StringVar(profile, "profile", "", "named profile in the config file to apply")
 	rootCmd.PersistentFlags().BoolVar(silent, "silent", false, "disable log(only show fatal log)")
 	rootCmd.PersistentFlags().BoolVar(verbose, "verbose", false, "enable debug log")
 
-	rootCmd.Flags().StringVarP(sep, "sep", "s", "", "string separator of a Benchmark string's target and scenario.\ne.g., we got a benchmark name string 'BenchmarkFibonacci/100times' with separator '/', then the target of it is 'Fibonacci' and the scenario of it is '100times'.\n")
-	rootCmd.Flags().StringVarP(regexStr, "regex", "r", "^Bench(mark)?(?<target>[A-Z]+\\S*)(?<scenario>[A-Z]+\\S*)$", "regexp expression with two sub groups(target and scenario), written in '.NET-style capture groups'--(?<name>re) or (?'name're).\ne.g., '^Bench(mark)?(?<target>\\S+/\\S+)/(?<scenario>\\S+)$'")
-	rootCmd.Flags().StringVarP(outputDir, "output", "o", ".", "directory path to save the output file")
-	rootCmd.Flags().BoolVar(jsonMode, "json", false, "only output parsed Benchmark result in json file")
-	rootCmd.Flags().Float64SliceVarP(&baselines, "baseline", "b", []float64{}, "baseline metrics to check, it must be a 3 elements array, which represents the baseline metrics of ns/op, B/op and allocs/op, e.g., [100, 1000, 10](set metric to <= 0 to disable baseline check for specific metric).)")
+	rootCmd.PersistentFlags().StringVarP(sep, "sep", "s", "", "string separator of a Benchmark string's target and scenario.\ne.g., we got a benchmark name string 'BenchmarkFibonacci/100times' with separator '/', then the target of it is 'Fibonacci' and the scenario of it is '100times'.\n")
+	rootCmd.PersistentFlags().StringVarP(regexStr, "regex", "r", "^Bench(mark)?(?<target>[A-Z]+\\S*)(?<scenario>[A-Z]+\\S*)$", "regexp expression with two sub groups(target and scenario), written in '.NET-style capture groups'--(?<name>re) or (?'name're).\ne.g., '^Bench(mark)?(?<target>\\S+/\\S+)/(?<scenario>\\S+)$'")
+	rootCmd.PersistentFlags().BoolVar(autoSplit, "auto", false, "infer the split of target and scenario from all Benchmark names of each package('/' levels, key=value segments...) instead of --sep / --regex, the inferred regexp is logged to be pinned by --regex")
+	rootCmd.PersistentFlags().StringArrayVar(&ruleSpecs, "rule", nil, "name rule tried in order before --sep / --regex(and before rules in config file), repeatable, in the form '[pkg=<package regexp>;]sep=<separator>' or '[pkg=<package regexp>;]regex=<regexp>', e.g. 'pkg=.*/fib$;sep=_'")
+	rootCmd.PersistentFlags().BoolVar(catchAll, "catch-all", false, "put the whole name of Benchmark which can not be split by any rule into target, with an empty scenario, instead of failing")
+	rootCmd.PersistentFlags().StringVar(&layout.Series, "series", layout.Series, "dimension of Benchmark as series of charts, 'target', 'scenario' or a named group of --regex")
+	rootCmd.PersistentFlags().StringVar(&layout.XAxis, "x-axis", layout.XAxis, "dimension of Benchmark as x axis of charts, 'target', 'scenario' or a named group of --regex")
+	rootCmd.PersistentFlags().StringVar(&layout.Split, "split-by", "", "dimension of Benchmark to split charts by, one chart per value for every metric")
+	rootCmd.PersistentFlags().StringVar(&layout.ErrorBars, "error-bars", visual.ErrorBarsNone, fmt.Sprintf("error bars over bars of Benchmark with repeated samples(e.g. by -count), one of [%s], ci is the 95%% confidence interval of the mean", strings.Join(visual.ErrorBarModes, ", ")))
+	rootCmd.PersistentFlags().BoolVar(&layout.BoxPlot, "box-plot", false, "add a box plot chart of the sample distribution of every target in every scenario for every metric")
+	rootCmd.PersistentFlags().StringVar(&layout.Reference, "reference-target", "", "add charts of every metric relative to the given target in the same scenario, e.g. 'Goroutines', with a 1.0 reference line")
+	rootCmd.PersistentFlags().BoolVar(&layout.Speedup, "speedup", false, "charts relative to --reference-target show speedup(higher is better for every metric) instead of ratio")
+	rootCmd.PersistentFlags().StringToStringVar(&rankWeightStr, "rank-weights", nil, "weights of metrics in the overall ranking of targets, e.g. ns/op=1,allocs/op=0.5, metrics without weight are left out (default ns/op=1,B/op=1,allocs/op=1)")
+	rootCmd.PersistentFlags().StringVarP(outputDir, "output", "o", ".", "directory path to save the output file")
+	rootCmd.PersistentFlags().BoolVar(jsonMode, "json", false, "only output parsed Benchmark result in json file")
+	rootCmd.PersistentFlags().StringVar(format, "format", formatHTML, fmt.Sprintf("output format, one of [%s]", strings.Join(outputFormats, ", ")))
+	rootCmd.PersistentFlags().StringVar(timestampStr, "timestamp", "", "timestamp of the Benchmark run in RFC3339 or unix seconds, used by time series formats like influx (default the run date recorded in Benchmark, or now)")
+	rootCmd.PersistentFlags().StringVar(pushURL, "push-url", "", "InfluxDB v2 write endpoint to POST the influx output to, e.g. 'http://localhost:8086/api/v2/write?org=my-org&bucket=bench'")
+	rootCmd.PersistentFlags().StringVar(pushToken, "push-token", "", "InfluxDB API token used with --push-url (default $INFLUX_TOKEN)")
+	rootCmd.PersistentFlags().StringVar(historyPath, "history", "", "JSON-lines history file to append every parsed run to(with its timestamp and git commit), and to read by 'history'")
+	rootCmd.PersistentFlags().StringVar(outlierMethod, "outliers", bench.OutlierIQR, fmt.Sprintf("method to detect outlier runs of a Benchmark(e.g. by -count), one of [%s], outliers are flagged in json and shown as points on charts", strings.Join(bench.OutlierMethods, ", ")))
+	rootCmd.PersistentFlags().BoolVar(dropOutliers, "drop-outliers", false, "remove outlier runs before aggregating and output, the number of removed runs is reported")
+	rootCmd.PersistentFlags().IntVar(&noiseOptions.MinIterations, "min-iterations", noiseOptions.MinIterations, "warn on Benchmark with fewer iterations(b.N) in a run, 0 to disable")
+	rootCmd.PersistentFlags().Float64Var(&noiseOptions.MaxCV, "max-cv", noiseOptions.MaxCV, "warn on Benchmark whose ns/op varies across runs by a higher coefficient of variation, e.g. 0.05 for 5%, 0 to disable")
+	rootCmd.PersistentFlags().IntVar(&noiseOptions.MinSamples, "min-samples", noiseOptions.MinSamples, "warn on Benchmark with fewer runs(by -count) than needed for significance, only when some Benchmark runs more than once, 0 to disable")
+	rootCmd.PersistentFlags().Float64SliceVarP(&baselines, "baseline", "b", []float64{}, "baseline metrics to check, it must be a 3 elements array, which represents the baseline metrics of ns/op, B/op and allocs/op, e.g., [100, 1000, 10](set metric to <= 0 to disable baseline check for specific metric).)")
 
 	rootCmd.MarkFlagsMutuallyExclusive("sep", "regex")
+	rootCmd.MarkFlagsMutuallyExclusive("auto", "sep")
+	rootCmd.MarkFlagsMutuallyExclusive("auto", "regex")
 	rootCmd.MarkFlagsMutuallyExclusive("silent", "verbose")
+	rootCmd.MarkFlagsMutuallyExclusive("json", "format")
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// benchmarkOutput raw go test output with noise around the Benchmark set, CRLF line endings and no trailing newline
+const benchmarkOutput = "go: downloading example.com/dep v1.0.0\n" +
+	"goos: linux\ngoarch: amd64\npkg: example.com/fib\r\n" +
+	"BenchmarkFib/10-8   \t 3033732\t       358.0 ns/op\t      16 B/op\t       1 allocs/op\n" +
+	"PASS\nok  \texample.com/fib\t1.234s\n\ttrailing log without newline"
+
+func TestParseInputTee(t *testing.T) {
+	convey.Convey("Given raw go test output", t, func() {
+		teePath := filepath.Join(t.TempDir(), "raw.txt")
+		defaultSep := *sep
+		*sep = "/"
+		defer func() { *sep = defaultSep }()
+
+		convey.Convey("Parse it with --tee, the raw input comes out byte for byte", func() {
+			sets, err := parseInput(strings.NewReader(benchmarkOutput), teePath, nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sets, convey.ShouldHaveLength, 1)
+			raw, err := os.ReadFile(teePath)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(raw), convey.ShouldEqual, benchmarkOutput)
+		})
+
+		convey.Convey("The rest of input is passed through when parsing fails", func() {
+			input := "goos: linux\nBenchmarkFib/10-8\tnot-a-number\t1 ns/op\nPASS\nthe rest\n"
+			_, err := parseInput(strings.NewReader(input), teePath, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			raw, err := os.ReadFile(teePath)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(raw), convey.ShouldEqual, input)
+		})
+	})
+}
+
+func TestCheckDimensions(t *testing.T) {
+	convey.Convey("Given Benchmark with a label and a parameter", t, func() {
+		sets := []bench.Set{{Targets: map[string]bench.BenchmarkList{
+			"Encode": {{Target: "Encode", Scenario: "small", Labels: map[string]string{"codec": "json"}, Params: bench.ParseParams("BenchmarkEncode/size=1024")}},
+		}}}
+		convey.So(checkDimensions(sets, visual.Layout{Series: "target", XAxis: "scenario"}), convey.ShouldBeNil)
+		convey.So(checkDimensions(sets, visual.Layout{Series: "codec", XAxis: "size", Split: "target"}), convey.ShouldBeNil)
+		err := checkDimensions(sets, visual.Layout{Series: "target", XAxis: "scenario", Split: "sise"})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(err.Error(), convey.ShouldContainSubstring, `--split-by "sise"`)
+	})
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/charmbracelet/log"
+	"github.com/dlclark/regexp2"
+	"github.com/spf13/cobra"
+)
+
+var rawPath = new(string)
+
+// rawFileName name of the file to tee the raw go test output to in the output directory, unless --raw is given
+const rawFileName = "raw_benchmark.txt"
+
+// rawOutputPath path to tee the raw go test output to, --raw or raw_benchmark.txt in the output directory
+func rawOutputPath() string {
+	if *rawPath != "" {
+		return *rawPath
+	}
+	return filepath.Join(*outputDir, rawFileName)
+}
+
+// runCmd run 'go test -bench' and visualize its output
+var runCmd = &cobra.Command{
+	Use:   "run [packages] [-- <go test flags>]",
+	Short: "Run 'go test -bench' and visualize its output",
+	Long: `Run 'go test -bench' and visualize its output.
+benchvisual invokes the go tool with the given packages('./...' in default) and go test flags('-run ^$ -bench . -benchmem' in default),
+streams the raw output to stdout and a file, parses it as it arrives and renders it at the end.
+the exact command line, Go version and git commit are recorded in each parsed Benchmark set,
+and the exit status of go test is kept as the exit status of benchvisual.`,
+	Example: `  benchvisual run -s '/'
+  benchvisual run ./internal/... -s '/' -- -run '^$' -bench 'AllRandFloat64' -benchmem -count 5`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		regex, err := prepare()
+		if err != nil {
+			return err
+		}
+
+		pkgs, flags := args, []string(nil)
+		if dashIdx := cmd.ArgsLenAtDash(); dashIdx != -1 {
+			pkgs, flags = args[:dashIdx], args[dashIdx:]
+		}
+		goTest, err := runner.NewGoTest(pkgs, flags)
+		if err != nil {
+			return err
+		}
+
+		sets, runErr := runBenchmark(cmd.Context(), goTest, regex, nil)
+		if sets == nil {
+			return runErr
+		}
+		if err = report(cmd.Context(), sets); err != nil {
+			return err
+		}
+		// exit with the status of go test after rendering what has been parsed
+		return runErr
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// runBenchmark run go test, tee its raw output, parse Benchmark as output arrives and record metadata of the run
+//
+//	@param ctx context.Context
+//	@param goTest *runner.GoTest
+//	@param regex *regexp2.Regexp
+//	@param onBenchmark bench.BenchmarkHandler called once a Benchmark is parsed, can be nil
+//	@return sets []bench.Set nil when nothing can be parsed
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 01:46:10
+func runBenchmark(ctx context.Context, goTest *runner.GoTest, regex *regexp2.Regexp, onBenchmark bench.BenchmarkHandler) (sets []bench.Set, err error) {
+	rawFile, err := os.Create(rawOutputPath())
+	if err != nil {
+		return nil, fmt.Errorf("error when create raw output file: %w", err)
+	}
+	defer rawFile.Close()
+
+	commandLine := goTest.CommandLine()
+	startTime := time.Now()
+	log.Info("running Benchmark", "command", commandLine, "raw output", rawFile.Name())
+
+	// parse output in a goroutine while go test is writing it
+	pipeReader, pipeWriter := io.Pipe()
+	type parseResult struct {
+		sets []bench.Set
+		err  error
+	}
+	parsed := make(chan parseResult, 1)
+	go func() {
+		sets, err := bench.Stream(ctx, pipeReader, *sep, regex, onBenchmark, scanOptions()...)
+		// drain the rest of output, or go test will be blocked on a parse error
+		_, _ = io.Copy(io.Discard, pipeReader)
+		parsed <- parseResult{sets: sets, err: err}
+	}()
+
+	runErr := goTest.Run(ctx, io.MultiWriter(os.Stdout, rawFile, pipeWriter), io.MultiWriter(os.Stderr, rawFile))
+	pipeWriter.Close()
+	result := <-parsed
+	if result.err != nil {
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, result.err
+	}
+	log.Info("Benchmark parsed success", "set_num", len(result.sets))
+
+	// record metadata of the run
+	goVersion, err := runner.GoVersion(ctx, goTest.GoBin)
+	if err != nil {
+		log.Warn("failed to get go version", "err", err)
+	}
+	commit, err := runner.GitCommit(ctx, goTest.Dir)
+	if err != nil {
+		log.Warn("failed to get git commit", "err", err)
+	}
+	for idx := range result.sets {
+		result.sets[idx].Command = commandLine
+		result.sets[idx].GoVersion = goVersion
+		result.sets[idx].Commit = commit
+		result.sets[idx].Date = startTime.Format(time.RFC3339)
+	}
+
+	return result.sets, runErr
+}
+
+func init() {
+	runCmd.Flags().StringVar(rawPath, "raw", "", "file path to tee the raw go test output to (default <output dir>/"+rawFileName+")")
+
+	rootCmd.AddCommand(runCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/runner"
+	"github.com/Kevinello/benchvisual/internal/server"
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listenAddr = new(string)
+	runMode    = new(bool)
+)
+
+// serveCmd serve live updated Benchmark pages over HTTP
+var serveCmd = &cobra.Command{
+	Use:   "serve [--listen <address>] [-f <benchmark path> | --run [packages] [-- <go test flags>]]",
+	Short: "Serve live updated Benchmark charts over HTTP",
+	Long: `Serve live updated Benchmark charts over HTTP.
+benchvisual reads Benchmark output from stdin(or the file given by -f, or runs 'go test -bench' itself with --run),
+parses it incrementally and serves a page for every package, charts on the page are updated through Server-Sent Events
+as soon as each Benchmark line arrives. The server keeps running after the input ends, until it is interrupted.
+pages show every parsed run as it is: unlike the rendered output, outliers are neither marked nor dropped(--outliers, --drop-outliers),
+noise is not checked and targets are not ranked, render the raw output afterwards for those.`,
+	Example: `  go test ./... -run '^$' -bench . -benchmem | benchvisual serve -s / --listen :8080
+  benchvisual serve -s / --run ./... -- -run '^$' -bench . -count 10`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		regex, err := prepare()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// listen first, fail fast when the address is in use
+		listener, err := net.Listen("tcp", *listenAddr)
+		if err != nil {
+			return err
+		}
+		srv := server.NewServer(layout)
+		onBenchmark := func(set *bench.Set, benchmark *bench.Benchmark) {
+			if benchFilter.Match(set.Pkg, benchmark) {
+				srv.AddBenchmark(set, benchmark)
+			}
+		}
+		httpServer := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("http server stopped", "err", err)
+				stop()
+			}
+		}()
+		log.Info("serving Benchmark pages", "address", "http://"+listener.Addr().String())
+
+		var consume func() ([]bench.Set, error)
+		if *runMode {
+			pkgs, flags := args, []string(nil)
+			if dashIdx := cmd.ArgsLenAtDash(); dashIdx != -1 {
+				pkgs, flags = args[:dashIdx], args[dashIdx:]
+			}
+			goTest, err := runner.NewGoTest(pkgs, flags)
+			if err != nil {
+				return err
+			}
+			consume = func() ([]bench.Set, error) { return runBenchmark(ctx, goTest, regex, onBenchmark) }
+		} else {
+			reader := io.Reader(os.Stdin)
+			if *filePath != "" {
+				f, err := os.Open(*filePath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				reader = f
+			}
+			consume = func() ([]bench.Set, error) {
+				return bench.Stream(ctx, reader, *sep, regex, onBenchmark, scanOptions()...)
+			}
+		}
+
+		// a read from a terminal can not always be interrupted, so stdin or a file is not waited for once interrupted,
+		// while go test is killed by ctx and waited for, to keep its raw output complete
+		consumed := make(chan struct{})
+		go func() {
+			defer close(consumed)
+			sets, err := consume()
+			if err != nil && ctx.Err() == nil {
+				log.Error("Benchmark input failed", "err", err)
+			}
+			log.Info("input finished, keep serving until interrupted", "set_num", len(sets))
+		}()
+		select {
+		case <-consumed:
+			<-ctx.Done()
+		case <-ctx.Done():
+			if *runMode {
+				<-consumed
+			}
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(listenAddr, "listen", ":8080", "address to serve Benchmark pages on")
+	serveCmd.Flags().BoolVar(runMode, "run", false, "run 'go test -bench' with the given packages and go test flags instead of reading stdin")
+	serveCmd.Flags().StringVarP(filePath, "file", "f", "", "read the original Benchmark output from the given file path instead of stdin")
+	serveCmd.Flags().StringVar(rawPath, "raw", "", "file path to tee the raw go test output to, used with --run (default <output dir>/"+rawFileName+")")
+
+	serveCmd.MarkFlagsMutuallyExclusive("run", "file")
+
+	rootCmd.AddCommand(serveCmd)
+}
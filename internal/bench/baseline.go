@@ -1,33 +1,76 @@
 package bench
 
-// Baseline compare benchmark result with baseline
+import (
+	"fmt"
+	"strconv"
+)
+
+// BaselineMiss a metric of benchmark which does not reach its baseline
+type BaselineMiss struct {
+	Metric    string  `json:"metric"`    // metric unit, e.g. ns/op
+	Threshold float64 `json:"threshold"` // baseline of the metric, the actual value should be less than it
+	Actual    float64 `json:"actual"`    // actual value used in comparison(ns/op is multiplied by cpu cores)
+}
+
+// String describe the miss in a human readable way
+//
+//	@receiver miss BaselineMiss
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 11:02:47
+func (miss BaselineMiss) String() string {
+	return fmt.Sprintf("%s: actual %s, should be less than baseline %s", miss.Metric,
+		strconv.FormatFloat(miss.Actual, 'f', -1, 64), strconv.FormatFloat(miss.Threshold, 'f', -1, 64))
+}
+
+// Baseline compare benchmark result with baseline, a Benchmark reaches baseline when every checked metric does,
+// a metric whose baseline <= 0 is disabled and never fails the Benchmark, as --baseline documents
+// (it used to make every Benchmark miss the baseline)
 //
 //	@param sets []Set
 //	@param baselines []float64
 //	@return []Set
 //	@author kevineluo
-//	@update 2023-04-18 04:14:32
+//	@update 2026-10-19 22:10:05
 func Baseline(sets []Set, baselines []float64) {
 	for setIdx, set := range sets {
 		for target, benchList := range set.Targets {
 			for idx, benchmark := range benchList {
-				var (
-					fast  bool
-					small bool
-					less  bool
-				)
-				if baselines[0] > 0 {
-					// consider cpu core nums when compare
-					fast = benchmark.NsPerOp*float64(benchmark.CPUCores) < baselines[0]
-				}
-				if baselines[1] > 0 {
-					small = benchmark.Mem.BytesPerOp < baselines[1]
-				}
-				if baselines[2] > 0 {
-					less = benchmark.Mem.AllocsPerOp < baselines[2]
-				}
-				sets[setIdx].Targets[target][idx].ReachBaseline = fast && small && less
+				misses := CheckBaseline(&benchmark, baselines)
+				sets[setIdx].Targets[target][idx].ReachBaseline = len(misses) == 0
+				sets[setIdx].Targets[target][idx].BaselineMisses = misses
 			}
 		}
 	}
 }
+
+// CheckBaseline check a single benchmark with baselines of ns/op, B/op and allocs/op,
+// a metric whose baseline <= 0 is not checked
+//
+//	@param benchmark *Benchmark
+//	@param baselines []float64
+//	@return misses []BaselineMiss metrics which do not reach baseline, empty when the benchmark reach baseline
+//	@author kevineluo
+//	@update 2026-10-19 11:02:47
+func CheckBaseline(benchmark *Benchmark, baselines []float64) (misses []BaselineMiss) {
+	// consider cpu core nums when compare
+	cores := benchmark.CPUCores
+	if cores < 1 {
+		cores = 1
+	}
+	checks := []BaselineMiss{
+		{Metric: "ns/op", Actual: benchmark.NsPerOp * float64(cores)},
+		{Metric: "B/op", Actual: benchmark.Mem.BytesPerOp},
+		{Metric: "allocs/op", Actual: benchmark.Mem.AllocsPerOp},
+	}
+	for idx, check := range checks {
+		if idx >= len(baselines) || baselines[idx] <= 0 {
+			continue
+		}
+		if check.Actual >= baselines[idx] {
+			check.Threshold = baselines[idx]
+			misses = append(misses, check)
+		}
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestBaseline(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets", t, func() {
+		sets := []Set{{
+			Targets: map[string]BenchmarkList{
+				"Fib": {
+					{Name: "BenchmarkFib/10", CPUCores: 4, NsPerOp: 20, Mem: Mem{BytesPerOp: 16, AllocsPerOp: 1}},
+					{Name: "BenchmarkFib/100", CPUCores: 4, NsPerOp: 200, Mem: Mem{BytesPerOp: 160, AllocsPerOp: 1}},
+				},
+			},
+		}}
+		convey.Convey("Check them with baselines of all metrics", func() {
+			Baseline(sets, []float64{100, 100, 10})
+			convey.So(sets[0].Targets["Fib"][0].ReachBaseline, convey.ShouldBeTrue)
+			convey.So(sets[0].Targets["Fib"][0].BaselineMisses, convey.ShouldBeEmpty)
+			convey.So(sets[0].Targets["Fib"][1].ReachBaseline, convey.ShouldBeFalse)
+			convey.So(sets[0].Targets["Fib"][1].BaselineMisses, convey.ShouldResemble, []BaselineMiss{
+				{Metric: "ns/op", Threshold: 100, Actual: 800},
+				{Metric: "B/op", Threshold: 100, Actual: 160},
+			})
+		})
+		convey.Convey("Metrics with baseline <= 0 are not checked", func() {
+			Baseline(sets, []float64{0, 1000, 0})
+			convey.So(sets[0].Targets["Fib"][0].ReachBaseline, convey.ShouldBeTrue)
+			convey.So(sets[0].Targets["Fib"][1].ReachBaseline, convey.ShouldBeTrue)
+
+			// a disabled metric never fails the Benchmark, however far it is off
+			Baseline(sets, []float64{-1, 1000, 1})
+			convey.So(sets[0].Targets["Fib"][1].ReachBaseline, convey.ShouldBeFalse)
+			convey.So(sets[0].Targets["Fib"][1].BaselineMisses, convey.ShouldResemble, []BaselineMiss{{Metric: "allocs/op", Threshold: 1, Actual: 1}})
+			Baseline(sets, []float64{0, 0, 0})
+			convey.So(sets[0].Targets["Fib"][1].ReachBaseline, convey.ShouldBeTrue)
+			convey.So(sets[0].Targets["Fib"][1].BaselineMisses, convey.ShouldBeEmpty)
+		})
+	})
+}
@@ -5,8 +5,9 @@ package bench
 
 import (
 	"bufio"
+	"context"
 	"fmt"
-	"io"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,11 +18,23 @@ import (
 
 // Set is a set of benchmark runs
 type Set struct {
+	SchemaVersion int `json:"schema_version,omitempty"` // version of the JSON schema, see SchemaVersion
+
 	Goos    string                   `json:"goos,omitempty"`
 	Goarch  string                   `json:"goarch,omitempty"`
 	Pkg     string                   `json:"pkg,omitempty"`
 	CPU     string                   `json:"cpu,omitempty"`
 	Targets map[string]BenchmarkList `json:"targets,omitempty"` // map[target][]Benchmark; group of Benchmark result(Series in visualized result)
+
+	// metadata of the run, recorded when benchvisual runs the Benchmark itself
+	Command   string `json:"command,omitempty"`    // exact command line of the run
+	GoVersion string `json:"go_version,omitempty"` // e.g. go1.20.3
+	Commit    string `json:"commit,omitempty"`     // git commit of the benchmarked code
+	Date      string `json:"date,omitempty"`       // start time of the run in RFC3339
+
+	OutliersDropped int `json:"outliers_dropped,omitempty"` // number of outlier samples removed by DropOutliers
+
+	Ranking *Ranking `json:"ranking,omitempty"` // leaderboard of targets, see Rank
 }
 
 // Benchmark is an individual run. Note that all metrics in here must be represented as
@@ -37,12 +50,21 @@ type Benchmark struct {
 	// The Benchmark of different target is compared in each Scenario
 	Target   string `json:"target,omitempty"`
 	Scenario string `json:"scenario,omitempty"`
+	// Labels other named groups of the regexp, e.g. (?<pool>\S+), as extra dimensions of the Benchmark
+	Labels map[string]string `json:"labels,omitempty"`
+	// Params key=value segments of the name, e.g. size=1024 in BenchmarkEncode/size=1024, as extra dimensions of the Benchmark
+	Params map[string]Param `json:"params,omitempty"`
 
 	NsPerOp       float64            `json:"ns_per_op,omitempty"`
 	Mem           Mem                `json:"mem,omitempty"`            // metrics from '-benchmem'
 	CustomMetrics map[string]float64 `json:"custom_metrics,omitempty"` // custom metrics(https://tip.golang.org/pkg/testing/#B.ReportMetric)
+	// Outliers units of metrics in which this run is an outlier among runs of the same Benchmark(e.g. by -count), see MarkOutliers
+	Outliers []string `json:"outliers,omitempty"`
+	// Warnings reasons why the result of the Benchmark is statistically weak, see CheckNoise
+	Warnings []NoiseWarning `json:"warnings,omitempty"`
 
-	ReachBaseline bool `json:"reach_baseline"` // whether this benchmark reach baseline
+	ReachBaseline  bool           `json:"reach_baseline"`            // whether this benchmark reach baseline
+	BaselineMisses []BaselineMiss `json:"baseline_misses,omitempty"` // metrics which do not reach baseline
 }
 
 // BenchmarkList implement sort.Interface
@@ -63,11 +85,112 @@ func (b BenchmarkList) Swap(i, j int) {
 	b[i], b[j] = b[j], b[i]
 }
 
+// Dimension value of a dimension of the Benchmark, which is 'target', 'scenario', a label or a parameter
+//
+//	@receiver b *Benchmark
+//	@param name string
+//	@return value string empty when the Benchmark has no such label or parameter
+//	@author kevineluo
+//	@update 2026-10-19 18:05:12
+func (b *Benchmark) Dimension(name string) (value string) {
+	switch name {
+	case "target":
+		return b.Target
+	case "scenario":
+		return b.Scenario
+	}
+	if value, ok := b.Labels[name]; ok {
+		return value
+	}
+	return b.Params[name].Value
+}
+
+// Metrics all metrics of the Benchmark keyed by unit, e.g. ns/op -> 358, custom metrics included
+//
+//	@receiver b *Benchmark
+//	@return metrics map[string]float64
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func (b *Benchmark) Metrics() (metrics map[string]float64) {
+	metrics = map[string]float64{
+		"ns/op":     b.NsPerOp,
+		"B/op":      b.Mem.BytesPerOp,
+		"allocs/op": b.Mem.AllocsPerOp,
+		"MB/s":      b.Mem.MBPerSec,
+	}
+	for unit, value := range b.CustomMetrics {
+		metrics[unit] = value
+	}
+	return
+}
+
+// Reports whether the Benchmark reports a metric, builtin metrics missing in output are 0 in Benchmark,
+// B/op and allocs/op of Benchmark parsed before Mem.Benchmem was recorded are taken as reported when not 0,
+// and so is MB/s which is only reported by Benchmark calling b.SetBytes
+//
+//	@receiver b *Benchmark
+//	@param unit string
+//	@return bool
+//	@author kevineluo
+//	@update 2026-10-19 23:52:16
+func (b *Benchmark) Reports(unit string) bool {
+	switch unit {
+	case "ns/op":
+		return true
+	case "B/op", "allocs/op":
+		return b.Mem.Benchmem || b.Mem.BytesPerOp != 0 || b.Mem.AllocsPerOp != 0
+	case "MB/s":
+		return b.Mem.MBPerSec != 0
+	}
+	_, ok := b.CustomMetrics[unit]
+	return ok
+}
+
+// builtinUnits units of builtin metrics, in the order of go test output
+var builtinUnits = []string{"ns/op", "B/op", "allocs/op", "MB/s"}
+
+// SortUnits order units of metrics for output, builtin metrics first in the order of go test output,
+// then custom metrics in order, units mapped to false are left out
+//
+//	@param reported map[string]bool unit -> whether to output it
+//	@return units []string
+//	@author kevineluo
+//	@update 2026-10-20 00:31:18
+func SortUnits(reported map[string]bool) (units []string) {
+	builtin := make(map[string]bool, len(builtinUnits))
+	for _, unit := range builtinUnits {
+		builtin[unit] = true
+		if reported[unit] {
+			units = append(units, unit)
+		}
+	}
+	custom := make([]string, 0, len(reported))
+	for unit, ok := range reported {
+		if ok && !builtin[unit] {
+			custom = append(custom, unit)
+		}
+	}
+	sort.Strings(custom)
+	return append(units, custom...)
+}
+
+// HigherIsBetter whether a larger value of the metric is better, true for throughput units like MB/s
+//
+//	@param unit string
+//	@return bool
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func HigherIsBetter(unit string) bool {
+	return strings.HasSuffix(unit, "/s")
+}
+
 // Mem is memory allocation information about a run
 type Mem struct {
 	BytesPerOp  float64 `json:"bytes_per_op,omitempty"`
 	AllocsPerOp float64 `json:"allocs_per_op,omitempty"`
 	MBPerSec    float64 `json:"mb_per_sec,omitempty"`
+	// Benchmem whether B/op and allocs/op are reported(by -benchmem or b.ReportAllocs), so that 0 is told from missing
+	Benchmem bool `json:"benchmem,omitempty"`
 }
 
 // ParseSet Parse one set of benchmark output
@@ -80,52 +203,18 @@ type Mem struct {
 //	@author kevineluo
 //	@update 2023-03-07 12:16:30
 func ParseSet(reader *bufio.Reader, sep string, regex *regexp2.Regexp) (set *Set, err error) {
-	set = &Set{
-		Targets: make(map[string]BenchmarkList),
-	}
-
-	for {
-		l, _, err := reader.ReadLine()
-		if err == io.EOF {
-			err = fmt.Errorf("found EOF before 'PASS' or 'FAIL'(the end of a Benchmark set)")
-			return nil, err
-		} else if err != nil {
-			return nil, err
-		}
-		line := string(l)
-
-		if strings.HasPrefix(line, "PASS") || strings.HasPrefix(line, "FAIL") {
-			// end of one set
-			log.Info("Benchmark set parsed")
-			break
-		} else if os, found := strings.CutPrefix(line, "goos: "); found {
-			set.Goos = os
-			log.Info("Benchmark metadata", "goos", set.Goos)
-		} else if arch, found := strings.CutPrefix(line, "goarch: "); found {
-			set.Goarch = arch
-			log.Info("Benchmark metadata", "goarch", set.Goarch)
-		} else if pkg, found := strings.CutPrefix(line, "pkg: "); found {
-			set.Pkg = pkg
-			log.Info("Benchmark metadata", "pkg", set.Pkg)
-		} else if cpu, found := strings.CutPrefix(line, "cpu: "); found {
-			set.CPU = cpu
-			log.Info("Benchmark metadata", "cpu", set.CPU)
-		} else if strings.HasPrefix(line, "Bench") {
-			log.Debug("[ParseSet] Benchmark line", "origin_line", line)
-			bench, err := ParseBench(line, sep, regex)
-			if err != nil {
-				return nil, fmt.Errorf("%w: %q", err, line)
-			}
-			log.Debug("Benchmark parsed", "name", bench.Name, "runs", bench.Runs, "target", bench.Target, "scenario", bench.Scenario)
-			if benchmarks, ok := set.Targets[bench.Target]; ok {
-				set.Targets[bench.Target] = append(benchmarks, *bench)
-			} else {
-				set.Targets[bench.Target] = []Benchmark{*bench}
-			}
+	scanner := NewScanner(context.Background(), reader, sep, regex)
+	// the set begins at the current position of reader
+	scanner.set = &Set{Targets: make(map[string]BenchmarkList)}
+	for scanner.Scan() {
+		if event := scanner.Event(); event.Type == EventSetEnd {
+			return event.Set, nil
 		}
 	}
-
-	return
+	if err = scanner.Err(); err == nil {
+		err = fmt.Errorf("found EOF before 'PASS' or 'FAIL'(the end of a Benchmark set)")
+	}
+	return nil, err
 }
 
 // GetScenarios get all unique scenario in a Benchmark set
@@ -155,6 +244,16 @@ func (set *Set) GetScenarios() (scenarios []string) {
 //	@author kevineluo
 //	@update 2023-03-07 12:11:18
 func ParseBench(line string, sep string, regex *regexp2.Regexp) (bench *Benchmark, err error) {
+	return parseBench(line, func(name string) (target, scenario string, labels map[string]string, err error) {
+		return SplitName(name, sep, regex)
+	})
+}
+
+// nameSplitter split a Benchmark name into target, scenario and labels
+type nameSplitter func(name string) (target, scenario string, labels map[string]string, err error)
+
+// parseBench parse a single line from a benchmark, its name is split by splitName
+func parseBench(line string, splitName nameSplitter) (bench *Benchmark, err error) {
 	bench = new(Benchmark)
 	// split out name
 	split := collections.Map(strings.Split(line, "\t"), func(s string) string {
@@ -162,55 +261,19 @@ func ParseBench(line string, sep string, regex *regexp2.Regexp) (bench *Benchmar
 	})
 	bench.Name, split = popLeft(split)
 
-	// parse cpu core nums
-	sepIdx := strings.LastIndex(bench.Name, "-")
-	if sepIdx == -1 {
-		return nil, fmt.Errorf("[ParseBench] invalid benchmark name, no '-' found")
-	}
-	bench.CPUCores, err = strconv.Atoi(bench.Name[sepIdx+1:])
-	if err != nil {
-		return nil, fmt.Errorf("[ParseBench] invalid benchmark name, failed to parse cpu core nums")
+	// parse cpu core nums, go test omits the '-N' suffix when GOMAXPROCS is 1,
+	// so a name ending with '-<number>' is ambiguous then, e.g. BenchmarkFib/-10 is read as BenchmarkFib/ on 10 cores
+	if sepIdx := strings.LastIndex(bench.Name, "-"); sepIdx != -1 {
+		if cores, err := strconv.Atoi(bench.Name[sepIdx+1:]); err == nil {
+			bench.CPUCores = cores
+			bench.Name = bench.Name[:sepIdx]
+		}
 	}
-	bench.Name = bench.Name[:sepIdx]
 
-	if regex != nil {
-		// with regexp
-		log.Debug("[ParseBench] in regex mode", "regexp", regex.String())
-		match, err := regex.FindStringMatch(bench.Name)
-		if err != nil {
-			return nil, fmt.Errorf("[ParseBench] error when parse [benchmark name: %s], [regexp: %s], error: %w", bench.Name, regex.String(), err)
-		}
-		if match == nil {
-			return nil, fmt.Errorf("[ParseBench] no match found in [benchmark name: %s], [regexp: %s]", bench.Name, regex.String())
-		}
-		if group := match.GroupByName("target"); group != nil {
-			bench.Target = group.String()
-		} else {
-			return nil, fmt.Errorf("[ParseBench] group 'target' not found in match result")
-		}
-		if group := match.GroupByName("scenario"); group != nil {
-			bench.Scenario = group.String()
-		} else {
-			return nil, fmt.Errorf("[ParseBench] group 'scenario' not found in match result")
-		}
-	} else if sep != "" {
-		// with separator
-		log.Debug("[ParseBench] in separator mode", "separator", sep)
-		var after string
-		var found bool
-		// Compatible for "Benchmark" and "Bench"
-		if after, found = strings.CutPrefix(bench.Name, "Benchmark"); !found {
-			if after, found = strings.CutPrefix(bench.Name, "Bench"); !found {
-				return nil, fmt.Errorf("[ParseBench] illegal Benchmark name: %s", bench.Name)
-			}
-		}
-		bench.Target, bench.Scenario, found = strings.Cut(after, sep)
-		if !found {
-			return nil, fmt.Errorf("[ParseBench] given separator[%s] not found in Benchmark name: %s", sep, bench.Name)
-		}
-	} else {
-		return nil, fmt.Errorf("neither given regexp expression(-regex) nor given separator(--sep)")
+	if bench.Target, bench.Scenario, bench.Labels, err = splitName(bench.Name); err != nil {
+		return nil, err
 	}
+	bench.Params = ParseParams(bench.Name)
 
 	// parse runs (doesn't include units)
 	tmp, split := popLeft(split)
@@ -232,8 +295,10 @@ func ParseBench(line string, sep string, regex *regexp2.Regexp) (bench *Benchmar
 			bench.NsPerOp, err = strconv.ParseFloat(value, 64)
 		case "B/op":
 			bench.Mem.BytesPerOp, err = strconv.ParseFloat(value, 64)
+			bench.Mem.Benchmem = true
 		case "allocs/op":
 			bench.Mem.AllocsPerOp, err = strconv.ParseFloat(value, 64)
+			bench.Mem.Benchmem = true
 		case "MB/s":
 			bench.Mem.MBPerSec, err = strconv.ParseFloat(value, 64)
 		default:
@@ -263,3 +328,70 @@ func popLeft(s []string) (string, []string) {
 	}
 	return strings.Trim(s[0], " "), s[1:]
 }
+
+// SplitName split a Benchmark name(without the '-N' cpu suffix) into target and scenario by regex, or by sep when regex is nil,
+// other named groups of regex are kept as labels
+//
+//	@param name string
+//	@param sep string
+//	@param regex *regexp2.Regexp
+//	@return target string
+//	@return scenario string
+//	@return labels map[string]string nil when regex has no other named group
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 17:41:50
+func SplitName(name string, sep string, regex *regexp2.Regexp) (target, scenario string, labels map[string]string, err error) {
+	if regex != nil {
+		// with regexp
+		log.Debug("[SplitName] in regex mode", "regexp", regex.String())
+		match, err := regex.FindStringMatch(name)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("[SplitName] error when parse [benchmark name: %s], [regexp: %s], error: %w", name, regex.String(), err)
+		}
+		if match == nil {
+			return "", "", nil, fmt.Errorf("[SplitName] no match found in [benchmark name: %s], [regexp: %s]", name, regex.String())
+		}
+		if group := match.GroupByName("target"); group != nil {
+			target = group.String()
+		} else {
+			return "", "", nil, fmt.Errorf("[SplitName] group 'target' not found in match result")
+		}
+		if group := match.GroupByName("scenario"); group != nil {
+			scenario = group.String()
+		} else {
+			return "", "", nil, fmt.Errorf("[SplitName] group 'scenario' not found in match result")
+		}
+		for _, group := range match.Groups() {
+			if group.Name == "target" || group.Name == "scenario" {
+				continue
+			}
+			// skip unnamed groups, which are named by their numbers
+			if _, err := strconv.Atoi(group.Name); err == nil {
+				continue
+			}
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[group.Name] = group.String()
+		}
+	} else if sep != "" {
+		// with separator
+		log.Debug("[SplitName] in separator mode", "separator", sep)
+		var after string
+		var found bool
+		// Compatible for "Benchmark" and "Bench"
+		if after, found = strings.CutPrefix(name, "Benchmark"); !found {
+			if after, found = strings.CutPrefix(name, "Bench"); !found {
+				return "", "", nil, fmt.Errorf("[SplitName] illegal Benchmark name: %s", name)
+			}
+		}
+		target, scenario, found = strings.Cut(after, sep)
+		if !found {
+			return "", "", nil, fmt.Errorf("[SplitName] given separator[%s] not found in Benchmark name: %s", sep, name)
+		}
+	} else {
+		return "", "", nil, fmt.Errorf("neither given regexp expression(-regex) nor given separator(--sep)")
+	}
+	return
+}
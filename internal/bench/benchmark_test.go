@@ -0,0 +1,39 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseBench(t *testing.T) {
+	convey.Convey("Parse cpu core nums from the name of Benchmark", t, func() {
+		benchmark, err := ParseBench("BenchmarkFib/10-8\t3033732\t358 ns/op", "/", nil)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(benchmark.Name, convey.ShouldEqual, "BenchmarkFib/10")
+		convey.So(benchmark.CPUCores, convey.ShouldEqual, 8)
+
+		convey.Convey("The suffix is omitted with GOMAXPROCS=1", func() {
+			benchmark, err := ParseBench("BenchmarkFib/10\t3033732\t358 ns/op", "/", nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(benchmark.Name, convey.ShouldEqual, "BenchmarkFib/10")
+			convey.So(benchmark.CPUCores, convey.ShouldEqual, 0)
+		})
+
+		convey.Convey("A trailing '-<number>' is always read as cpu core nums", func() {
+			// scenario -10 run with GOMAXPROCS=1 can not be told from scenario '' on 10 cores
+			benchmark, err := ParseBench("BenchmarkFib/-10\t3033732\t358 ns/op", "/", nil)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(benchmark.Name, convey.ShouldEqual, "BenchmarkFib/")
+			convey.So(benchmark.CPUCores, convey.ShouldEqual, 10)
+		})
+	})
+
+	convey.Convey("Baseline of ns/op counts a Benchmark without cpu core nums as 1 core", t, func() {
+		sets := []Set{{Targets: map[string]BenchmarkList{"Fib": {{Name: "BenchmarkFib", NsPerOp: 200, Mem: Mem{BytesPerOp: 1, AllocsPerOp: 1}}}}}}
+		Baseline(sets, []float64{100, 10, 10})
+		convey.So(sets[0].Targets["Fib"][0].ReachBaseline, convey.ShouldBeFalse)
+		Baseline(sets, []float64{300, 10, 10})
+		convey.So(sets[0].Targets["Fib"][0].ReachBaseline, convey.ShouldBeTrue)
+	})
+}
@@ -0,0 +1,108 @@
+package bench
+
+import (
+	"regexp"
+)
+
+// Matcher include / exclude patterns of a string field
+//
+//	@author kevineluo
+//	@update 2026-10-19 16:31:05
+type Matcher struct {
+	Include []*regexp.Regexp // the string should match one of them, nothing is filtered out when empty
+	Exclude []*regexp.Regexp // the string should match none of them
+}
+
+// Match whether the string passes the matcher
+//
+//	@receiver m Matcher
+//	@param s string
+//	@return bool
+//	@author kevineluo
+//	@update 2026-10-19 16:31:05
+func (m Matcher) Match(s string) bool {
+	for _, pattern := range m.Exclude {
+		if pattern.MatchString(s) {
+			return false
+		}
+	}
+	if len(m.Include) == 0 {
+		return true
+	}
+	for _, pattern := range m.Include {
+		if pattern.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter filter parsed Benchmark by package, target, scenario and name, a Benchmark is kept only if it passes all matchers
+//
+//	@author kevineluo
+//	@update 2026-10-19 16:31:05
+type Filter struct {
+	Pkg      Matcher
+	Target   Matcher
+	Scenario Matcher
+	Name     Matcher // full Benchmark name, e.g. BenchmarkFibonacci/100times
+	// Dimensions matchers of other dimensions(labels and parameters), keyed by dimension name
+	Dimensions map[string]Matcher
+}
+
+// Match whether a Benchmark of the given package passes the filter
+//
+//	@receiver f *Filter
+//	@param pkg string
+//	@param benchmark *Benchmark
+//	@return bool
+//	@author kevineluo
+//	@update 2026-10-19 18:05:12
+func (f *Filter) Match(pkg string, benchmark *Benchmark) bool {
+	if !f.Pkg.Match(pkg) ||
+		!f.Target.Match(benchmark.Target) ||
+		!f.Scenario.Match(benchmark.Scenario) ||
+		!f.Name.Match(benchmark.Name) {
+		return false
+	}
+	for dimension, matcher := range f.Dimensions {
+		if !matcher.Match(benchmark.Dimension(dimension)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply filter Benchmark sets, targets and sets left with no Benchmark are dropped
+//
+//	@receiver f *Filter
+//	@param sets []Set
+//	@return filtered []Set
+//	@author kevineluo
+//	@update 2026-10-19 16:31:05
+func (f *Filter) Apply(sets []Set) (filtered []Set) {
+	filtered = make([]Set, 0, len(sets))
+	for _, set := range sets {
+		if !f.Pkg.Match(set.Pkg) {
+			continue
+		}
+		targets := make(map[string]BenchmarkList, len(set.Targets))
+		for target, benchmarks := range set.Targets {
+			kept := make(BenchmarkList, 0, len(benchmarks))
+			for idx := range benchmarks {
+				if f.Match(set.Pkg, &benchmarks[idx]) {
+					kept = append(kept, benchmarks[idx])
+				}
+			}
+			if len(kept) > 0 {
+				targets[target] = kept
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		set.Targets = targets
+		filtered = append(filtered, set)
+	}
+	return
+}
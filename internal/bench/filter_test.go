@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilter(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets", t, func() {
+		sets := []Set{targetSets[1]}
+
+		convey.Convey("Empty filter keeps everything", func() {
+			convey.So((&Filter{}).Apply(sets), convey.ShouldResemble, sets)
+		})
+
+		convey.Convey("Include target and exclude scenario", func() {
+			filter := &Filter{
+				Target:   Matcher{Include: []*regexp.Regexp{regexp.MustCompile(`^Fib$`)}},
+				Scenario: Matcher{Exclude: []*regexp.Regexp{regexp.MustCompile(`^100$`)}},
+			}
+			filtered := filter.Apply(sets)
+			convey.So(filtered, convey.ShouldHaveLength, 1)
+			convey.So(filtered[0].Targets, convey.ShouldHaveLength, 1)
+			convey.So(filtered[0].Targets["Fib"], convey.ShouldResemble, BenchmarkList{targetSets[1].Targets["Fib"][0]})
+			// the original sets are not modified
+			convey.So(sets[0].Targets, convey.ShouldHaveLength, 2)
+		})
+
+		convey.Convey("Filter by dimension", func() {
+			withParams := []Set{{Pkg: "demo", Targets: map[string]BenchmarkList{"Encode": {
+				{Name: "BenchmarkEncode/size=10", Target: "Encode", Params: ParseParams("BenchmarkEncode/size=10")},
+				{Name: "BenchmarkEncode/size=1024", Target: "Encode", Params: ParseParams("BenchmarkEncode/size=1024")},
+			}}}}
+			filter := &Filter{Dimensions: map[string]Matcher{"size": {Include: []*regexp.Regexp{regexp.MustCompile(`^1024$`)}}}}
+			filtered := filter.Apply(withParams)
+			convey.So(filtered[0].Targets["Encode"], convey.ShouldHaveLength, 1)
+			convey.So(filtered[0].Targets["Encode"][0].Name, convey.ShouldEqual, "BenchmarkEncode/size=1024")
+		})
+
+		convey.Convey("Sets left with no Benchmark are dropped", func() {
+			filter := &Filter{Name: Matcher{Include: []*regexp.Regexp{regexp.MustCompile(`Sleep`)}}}
+			convey.So(filter.Apply(sets), convey.ShouldBeEmpty)
+			filter = &Filter{Pkg: Matcher{Exclude: []*regexp.Regexp{regexp.MustCompile(`/demo$`)}}}
+			convey.So(filter.Apply(sets), convey.ShouldBeEmpty)
+		})
+	})
+}
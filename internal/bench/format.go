@@ -0,0 +1,102 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/collections"
+)
+
+// Write serialise Benchmark sets back into Golang standard benchmark output,
+// which can be parsed again by Parse or consumed by tools like benchstat
+//
+//	@param w io.Writer
+//	@param sets []Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 10:41:26
+func Write(w io.Writer, sets []Set) (err error) {
+	for idx := range sets {
+		if idx > 0 {
+			if _, err = io.WriteString(w, "\n"); err != nil {
+				return
+			}
+		}
+		if err = WriteSet(w, &sets[idx]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WriteSet serialise one set of benchmark, config lines first, then result lines, end with 'PASS'
+//
+//	@param w io.Writer
+//	@param set *Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 10:41:26
+func WriteSet(w io.Writer, set *Set) (err error) {
+	builder := new(strings.Builder)
+	configs := [][2]string{
+		{"goos", set.Goos}, {"goarch", set.Goarch}, {"pkg", set.Pkg}, {"cpu", set.CPU},
+		{"command", set.Command}, {"go", set.GoVersion}, {"commit", set.Commit}, {"date", set.Date},
+	}
+	for _, config := range configs {
+		if config[1] != "" {
+			fmt.Fprintf(builder, "%s: %s\n", config[0], config[1])
+		}
+	}
+
+	targets := collections.Keys(set.Targets)
+	sort.Strings(targets)
+	for _, target := range targets {
+		for idx := range set.Targets[target] {
+			builder.WriteString(FormatBench(&set.Targets[target][idx]))
+			builder.WriteByte('\n')
+		}
+	}
+	builder.WriteString("PASS\n")
+
+	_, err = io.WriteString(w, builder.String())
+	return
+}
+
+// FormatBench format a single benchmark into a result line, the reverse of ParseBench
+//
+// Metrics are written in the same order as the testing package does:
+//
+//	BenchmarkXXX-8	300000	5160 ns/op	12.5 MB/s	3.00 pizzas	5408 B/op	69 allocs/op
+//	@param bench *Benchmark
+//	@return line string
+//	@author kevineluo
+//	@update 2026-10-19 10:41:26
+func FormatBench(bench *Benchmark) (line string) {
+	name := bench.Name
+	if bench.CPUCores > 0 {
+		name = fmt.Sprintf("%s-%d", name, bench.CPUCores)
+	}
+	fields := []string{name, strconv.Itoa(bench.Runs), formatMetric(bench.NsPerOp, "ns/op")}
+
+	if bench.Mem.MBPerSec != 0 {
+		fields = append(fields, formatMetric(bench.Mem.MBPerSec, "MB/s"))
+	}
+	units := collections.Keys(bench.CustomMetrics)
+	sort.Strings(units)
+	for _, unit := range units {
+		fields = append(fields, formatMetric(bench.CustomMetrics[unit], unit))
+	}
+	if bench.Reports("B/op") {
+		fields = append(fields, formatMetric(bench.Mem.BytesPerOp, "B/op"), formatMetric(bench.Mem.AllocsPerOp, "allocs/op"))
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// formatMetric format a metric value with its unit, using the shortest representation which keeps the value lossless
+func formatMetric(value float64, unit string) string {
+	return strconv.FormatFloat(value, 'f', -1, 64) + " " + unit
+}
@@ -0,0 +1,53 @@
+package bench
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dlclark/regexp2"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormatBench(t *testing.T) {
+	convey.Convey("Given parsed Benchmarks", t, func() {
+		convey.Convey("Format them back into result lines", func() {
+			convey.So(FormatBench(&targetSets[0].Targets["Pizzas"][0]), convey.ShouldEqual, "BenchmarkPizzas10\t25820055\t50 ns/op\t3 pizzas")
+			convey.So(FormatBench(&targetSets[1].Targets["Fib"][1]), convey.ShouldEqual, "BenchmarkFib/100\t303373\t358 ns/op\t16 B/op\t1 allocs/op")
+			convey.So(FormatBench(&Benchmark{Name: "BenchmarkFib/100", CPUCores: 16, Runs: 3, NsPerOp: 1.5, Mem: Mem{MBPerSec: 12.25}}), convey.ShouldEqual, "BenchmarkFib/100-16\t3\t1.5 ns/op\t12.25 MB/s")
+		})
+	})
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	convey.Convey("Given Golang standard Benchmark outputs", t, func() {
+		regex := regexp2.MustCompile("^Bench(mark)?(?<target>[A-Z][a-z]*)(?<scenario>[^A-Z]\\S+)", 0)
+		fileOutput, err := os.ReadFile("../../tests/benchmark.txt")
+		convey.So(err, convey.ShouldBeNil)
+
+		cases := []struct {
+			output string
+			sep    string
+			regex  *regexp2.Regexp
+		}{
+			{output: benchmarkOutputs[0], regex: regex},
+			{output: benchmarkOutputs[1] + "\n" + benchmarkOutputs[1], sep: "/"},
+			{output: string(fileOutput), sep: "/"},
+		}
+		convey.Convey("parse -> write -> parse should be lossless", func() {
+			for _, c := range cases {
+				sets, err := Parse(bufio.NewReader(strings.NewReader(c.output)), c.sep, c.regex)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(sets, convey.ShouldNotBeEmpty)
+
+				builder := new(strings.Builder)
+				convey.So(Write(builder, sets), convey.ShouldBeNil)
+
+				reparsed, err := Parse(bufio.NewReader(strings.NewReader(builder.String())), c.sep, c.regex)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(reparsed, convey.ShouldResemble, sets)
+			}
+		})
+	})
+}
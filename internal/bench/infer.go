@@ -0,0 +1,86 @@
+package bench
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+)
+
+// inferCandidate a candidate regexp to split Benchmark names
+type inferCandidate struct {
+	expr string
+	// score of the split, candidates splitting names into more than one target and scenario are preferred,
+	// then the ones whose targets are compared in more scenarios(density of the target x scenario matrix)
+	multi   bool
+	density float64
+}
+
+// InferNameRegex infer a regexp splitting all the given Benchmark names(without the '-N' cpu suffix) into target and scenario,
+// candidates are '/'-separated levels, key=value segments, '_' separator and trailing numbers,
+// the candidate matching all names with the densest target x scenario matrix wins
+//
+//	@param names []string
+//	@return expr string regexp in the syntax of --regex
+//	@return err error no candidate matches all names
+//	@author kevineluo
+//	@update 2026-10-19 17:20:36
+func InferNameRegex(names []string) (expr string, err error) {
+	if len(names) == 0 {
+		return "", fmt.Errorf("[InferNameRegex] no Benchmark name to infer from")
+	}
+
+	var best *inferCandidate
+	for _, expr := range inferCandidateExprs(names) {
+		candidate, ok := scoreCandidate(expr, names)
+		if !ok {
+			continue
+		}
+		if best == nil || candidate.multi && !best.multi || candidate.multi == best.multi && candidate.density > best.density {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("[InferNameRegex] no split matches all of %d Benchmark names, e.g. %s", len(names), names[0])
+	}
+	return best.expr, nil
+}
+
+// inferCandidateExprs candidate regexps in order of preference
+func inferCandidateExprs(names []string) (exprs []string) {
+	// key=value segments, e.g. BenchmarkFoo/size=10/workers=4 -> Foo, size=10/workers=4
+	exprs = append(exprs, `^Bench(mark)?(?<target>[^=]+?)/(?<scenario>[^/=]+=.*)$`)
+	// '/'-separated levels, the first k levels are target
+	maxLevels := 0
+	for _, name := range names {
+		if levels := strings.Count(name, "/") + 1; levels > maxLevels {
+			maxLevels = levels
+		}
+	}
+	for k := 1; k < maxLevels; k++ {
+		exprs = append(exprs, fmt.Sprintf(`^Bench(mark)?(?<target>[^/]+%s)/(?<scenario>.+)$`, strings.Repeat(`/[^/]+`, k-1)))
+	}
+	// '_' separator, e.g. BenchmarkBar_10 -> Bar, 10
+	exprs = append(exprs, `^Bench(mark)?(?<target>[^_/]+)_(?<scenario>.+)$`)
+	// trailing numbers, e.g. BenchmarkFib100 -> Fib, 100
+	exprs = append(exprs, `^Bench(mark)?(?<target>\D+)(?<scenario>\d.*)$`)
+	return
+}
+
+// scoreCandidate split all names by the candidate regexp, ok is false when some name does not match
+func scoreCandidate(expr string, names []string) (candidate *inferCandidate, ok bool) {
+	regex := regexp2.MustCompile(expr, 0)
+	targets, scenarios, pairs := make(map[string]struct{}), make(map[string]struct{}), make(map[[2]string]struct{})
+	for _, name := range names {
+		target, scenario, _, err := SplitName(name, "", regex)
+		if err != nil || target == "" || scenario == "" {
+			return nil, false
+		}
+		targets[target], scenarios[scenario], pairs[[2]string{target, scenario}] = struct{}{}, struct{}{}, struct{}{}
+	}
+	return &inferCandidate{
+		expr:    expr,
+		multi:   len(targets) > 1 && len(scenarios) > 1,
+		density: float64(len(pairs)) / float64(len(targets)*len(scenarios)),
+	}, true
+}
@@ -0,0 +1,70 @@
+package bench
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dlclark/regexp2"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestInferNameRegex(t *testing.T) {
+	convey.Convey("Infer the split of Benchmark names", t, func() {
+		cases := []struct {
+			names    []string
+			expected [][2]string
+		}{
+			{
+				names:    []string{"BenchmarkFib10", "BenchmarkFib100", "BenchmarkPizzas10", "BenchmarkPizzas100"},
+				expected: [][2]string{{"Fib", "10"}, {"Fib", "100"}, {"Pizzas", "10"}, {"Pizzas", "100"}},
+			},
+			{
+				names:    []string{"BenchmarkFib/10", "BenchmarkFib/100", "BenchmarkPizzas/10", "BenchmarkPizzas/100"},
+				expected: [][2]string{{"Fib", "10"}, {"Fib", "100"}, {"Pizzas", "10"}, {"Pizzas", "100"}},
+			},
+			{
+				// the first level is shared by all names, targets are the second level
+				names: []string{"BenchmarkAll/Ants/1u", "BenchmarkAll/Ants/1Ku", "BenchmarkAll/Pond/1u", "BenchmarkAll/Pond/1Ku"},
+				expected: [][2]string{
+					{"All/Ants", "1u"}, {"All/Ants", "1Ku"}, {"All/Pond", "1u"}, {"All/Pond", "1Ku"},
+				},
+			},
+			{
+				names: []string{"BenchmarkMap/sync/size=10/workers=4", "BenchmarkMap/sync/size=100/workers=4", "BenchmarkMap/lock/size=10/workers=4"},
+				expected: [][2]string{
+					{"Map/sync", "size=10/workers=4"}, {"Map/sync", "size=100/workers=4"}, {"Map/lock", "size=10/workers=4"},
+				},
+			},
+			{
+				names:    []string{"BenchmarkBar_10", "BenchmarkBar_100", "BenchmarkBaz_10"},
+				expected: [][2]string{{"Bar", "10"}, {"Bar", "100"}, {"Baz", "10"}},
+			},
+		}
+		for _, c := range cases {
+			expr, err := InferNameRegex(c.names)
+			convey.So(err, convey.ShouldBeNil)
+			rule := NameRule{Regex: regexp2.MustCompile(expr, 0)}
+			for idx, name := range c.names {
+				target, scenario, _, err := rule.Split(name)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So([2]string{target, scenario}, convey.ShouldEqual, c.expected[idx])
+			}
+		}
+
+		_, err := InferNameRegex([]string{"BenchmarkFoo", "BenchmarkBar"})
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+
+	convey.Convey("Scan in auto mode", t, func() {
+		scanner := NewScanner(context.Background(), strings.NewReader(benchmarkOutputs[0]), "", nil, WithAutoSplit())
+		var sets []Set
+		for scanner.Scan() {
+			if event := scanner.Event(); event.Type == EventSetEnd {
+				sets = append(sets, *event.Set)
+			}
+		}
+		convey.So(scanner.Err(), convey.ShouldBeNil)
+		convey.So(sets, convey.ShouldResemble, []Set{targetSets[0]})
+	})
+}
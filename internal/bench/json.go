@@ -0,0 +1,60 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// SchemaVersion version of the JSON schema of Set written by WriteJSON, bump it on incompatible changes
+// and migrate older sets in ReadJSON.
+// Sets exported before the field was introduced have no schema_version and are read as version 0,
+// which is compatible with version 1
+const SchemaVersion = 1
+
+// WriteJSON write Benchmark sets as an indented JSON array, every set is stamped with SchemaVersion
+//
+//	@param w io.Writer
+//	@param sets []Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:26:37
+func WriteJSON(w io.Writer, sets []Set) (err error) {
+	stamped := make([]Set, len(sets))
+	for idx := range sets {
+		stamped[idx] = sets[idx]
+		stamped[idx].SchemaVersion = SchemaVersion
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(stamped)
+}
+
+// ReadJSON read Benchmark sets written by WriteJSON(or the json output of older versions)
+//
+//	@param r io.Reader
+//	@return sets []Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:26:37
+func ReadJSON(r io.Reader) (sets []Set, err error) {
+	if err = json.NewDecoder(r).Decode(&sets); err != nil {
+		return nil, fmt.Errorf("[ReadJSON] error when decode Benchmark sets: %w", err)
+	}
+	for idx := range sets {
+		set := &sets[idx]
+		if set.SchemaVersion > SchemaVersion {
+			return nil, fmt.Errorf("[ReadJSON] schema version %d of set %q is newer than the supported version %d, please upgrade benchvisual",
+				set.SchemaVersion, set.Pkg, SchemaVersion)
+		}
+		// version 0 -> 1: no field changed, only schema_version is introduced
+		set.SchemaVersion = SchemaVersion
+		if set.Targets == nil {
+			set.Targets = make(map[string]BenchmarkList)
+		}
+	}
+	return
+}
@@ -0,0 +1,40 @@
+package bench
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestJSON(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets", t, func() {
+		convey.Convey("Write and read them back", func() {
+			buffer := new(bytes.Buffer)
+			convey.So(WriteJSON(buffer, targetSets), convey.ShouldBeNil)
+			convey.So(buffer.String(), convey.ShouldContainSubstring, `"schema_version": 1`)
+			// the sets written are not modified
+			convey.So(targetSets[0].SchemaVersion, convey.ShouldEqual, 0)
+
+			sets, err := ReadJSON(buffer)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sets, convey.ShouldHaveLength, len(targetSets))
+			for idx := range sets {
+				convey.So(sets[idx].SchemaVersion, convey.ShouldEqual, SchemaVersion)
+				sets[idx].SchemaVersion = 0
+				convey.So(sets[idx], convey.ShouldResemble, targetSets[idx])
+			}
+		})
+		convey.Convey("Read json without schema version exported by older versions", func() {
+			sets, err := ReadJSON(strings.NewReader(`[{"pkg": "demo", "targets": {"Fib": [{"name": "BenchmarkFib/10", "target": "Fib", "scenario": "10", "ns_per_op": 358, "reach_baseline": false}]}}]`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sets[0].SchemaVersion, convey.ShouldEqual, SchemaVersion)
+			convey.So(sets[0].Targets["Fib"][0].NsPerOp, convey.ShouldEqual, 358)
+		})
+		convey.Convey("Refuse json of a newer schema", func() {
+			_, err := ReadJSON(strings.NewReader(`[{"schema_version": 99, "pkg": "demo"}]`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,29 @@
+package bench
+
+// MergeSets merge sets of the same package into one set, in the order of their first appearance,
+// metadata is taken from the first set of a package and Benchmark of later sets are appended to it
+//
+//	@param sets []Set
+//	@return merged []Set
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func MergeSets(sets []Set) (merged []Set) {
+	setIdx := make(map[string]int)
+	for _, set := range sets {
+		idx, ok := setIdx[set.Pkg]
+		if !ok {
+			setIdx[set.Pkg] = len(merged)
+			targets := make(map[string]BenchmarkList, len(set.Targets))
+			for target, benchmarks := range set.Targets {
+				targets[target] = append(BenchmarkList(nil), benchmarks...)
+			}
+			set.Targets = targets
+			merged = append(merged, set)
+			continue
+		}
+		for target, benchmarks := range set.Targets {
+			merged[idx].Targets[target] = append(merged[idx].Targets[target], benchmarks...)
+		}
+	}
+	return
+}
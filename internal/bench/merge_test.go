@@ -0,0 +1,30 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestMergeSets(t *testing.T) {
+	convey.Convey("Merge sets of the same package", t, func() {
+		first := Set{Pkg: "demo", CPU: "cpu", Targets: map[string]BenchmarkList{"Fib": {{Name: "BenchmarkFib/10", NsPerOp: 1}}}}
+		sets := []Set{
+			first,
+			{Pkg: "other", Targets: map[string]BenchmarkList{"Sum": {{Name: "BenchmarkSum", NsPerOp: 3}}}},
+			{Pkg: "demo", Targets: map[string]BenchmarkList{"Fib": {{Name: "BenchmarkFib/10", NsPerOp: 2}}, "Pow": {{Name: "BenchmarkPow", NsPerOp: 4}}}},
+		}
+		merged := MergeSets(sets)
+		convey.So(merged, convey.ShouldHaveLength, 2)
+		convey.So(merged[0].Pkg, convey.ShouldEqual, "demo")
+		convey.So(merged[0].CPU, convey.ShouldEqual, "cpu")
+		convey.So(merged[0].Targets["Fib"], convey.ShouldHaveLength, 2)
+		convey.So(merged[0].Targets["Fib"][1].NsPerOp, convey.ShouldEqual, 2)
+		convey.So(merged[0].Targets["Pow"], convey.ShouldHaveLength, 1)
+		convey.So(merged[1].Pkg, convey.ShouldEqual, "other")
+
+		convey.Convey("Leave the input untouched", func() {
+			convey.So(first.Targets["Fib"], convey.ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package bench
+
+import (
+	"fmt"
+
+	"github.com/Kevinello/benchvisual/internal/stats"
+)
+
+// kinds of noise warnings
+const (
+	WarningLowIterations = "low-iterations" // too few iterations(b.N) in a run
+	WarningHighVariance  = "high-variance"  // high coefficient of variation of ns/op across runs
+	WarningFewSamples    = "few-samples"    // too few runs(by -count) to tell a significant change
+)
+
+// NoiseOptions thresholds of a statistically weak Benchmark result, a threshold <= 0 is not checked
+type NoiseOptions struct {
+	MinIterations int     // minimal iterations(b.N) of a run, e.g. 100
+	MaxCV         float64 // maximal coefficient of variation of ns/op across runs, e.g. 0.05 for 5%
+	MinSamples    int     // minimal runs of a Benchmark, e.g. 5, only checked when some Benchmark has more than one run(by -count)
+}
+
+// DefaultNoiseOptions default thresholds of noise warnings
+var DefaultNoiseOptions = NoiseOptions{MinIterations: 100, MaxCV: 0.05, MinSamples: 5}
+
+// NoiseWarning a reason why the result of a Benchmark is statistically weak
+type NoiseWarning struct {
+	Kind    string `json:"kind"`    // one of WarningLowIterations, WarningHighVariance and WarningFewSamples
+	Message string `json:"message"` // human readable detail, e.g. only 3 iterations per run
+}
+
+// CheckNoise check runs of every Benchmark(same package, name and labels) against the thresholds,
+// warnings are set to Benchmark.Warnings of all runs of a weak Benchmark.
+// runs are only checked against MinSamples when some Benchmark runs more than once, so a plain run without -count is not warned
+//
+//	@param sets []Set
+//	@param options NoiseOptions
+//	@return weak int number of weak Benchmark(not runs)
+//	@author kevineluo
+//	@update 2026-10-20 01:04:27
+func CheckNoise(sets []Set, options NoiseOptions) (weak int) {
+	// runs of every Benchmark in every set, in order of appearance
+	runsOfSets := make([]map[string][]*Benchmark, len(sets))
+	keysOfSets := make([][]string, len(sets))
+	repeated := false
+	for setIdx, set := range sets {
+		runs := make(map[string][]*Benchmark)
+		for target := range set.Targets {
+			for idx := range set.Targets[target] {
+				benchmark := &set.Targets[target][idx]
+				key := runKey(benchmark)
+				if _, ok := runs[key]; !ok {
+					keysOfSets[setIdx] = append(keysOfSets[setIdx], key)
+				}
+				runs[key] = append(runs[key], benchmark)
+				repeated = repeated || len(runs[key]) > 1
+			}
+		}
+		runsOfSets[setIdx] = runs
+	}
+	if !repeated {
+		options.MinSamples = 0
+	}
+
+	for setIdx, runs := range runsOfSets {
+		for _, key := range keysOfSets[setIdx] {
+			warnings := checkRuns(runs[key], options)
+			for _, benchmark := range runs[key] {
+				benchmark.Warnings = warnings
+			}
+			if len(warnings) > 0 {
+				weak++
+			}
+		}
+	}
+	return
+}
+
+// checkRuns warnings of runs of a Benchmark
+func checkRuns(benchmarks []*Benchmark, options NoiseOptions) (warnings []NoiseWarning) {
+	minIterations := benchmarks[0].Runs
+	nsPerOps := make([]float64, len(benchmarks))
+	for idx, benchmark := range benchmarks {
+		if benchmark.Runs < minIterations {
+			minIterations = benchmark.Runs
+		}
+		nsPerOps[idx] = benchmark.NsPerOp
+	}
+
+	if options.MinIterations > 0 && minIterations < options.MinIterations {
+		warnings = append(warnings, NoiseWarning{
+			Kind:    WarningLowIterations,
+			Message: fmt.Sprintf("only %d iterations in a run, at least %d are expected, raise -benchtime", minIterations, options.MinIterations),
+		})
+	}
+	if mean := stats.Mean(nsPerOps); options.MaxCV > 0 && len(nsPerOps) >= 2 && mean > 0 {
+		if cv := stats.StdDev(nsPerOps) / mean; cv > options.MaxCV {
+			warnings = append(warnings, NoiseWarning{
+				Kind:    WarningHighVariance,
+				Message: fmt.Sprintf("ns/op varies by %.1f%% across runs, at most %.1f%% is expected", cv*100, options.MaxCV*100),
+			})
+		}
+	}
+	if options.MinSamples > 0 && len(benchmarks) < options.MinSamples {
+		warnings = append(warnings, NoiseWarning{
+			Kind:    WarningFewSamples,
+			Message: fmt.Sprintf("only %d runs, at least %d are expected for significance, raise -count", len(benchmarks), options.MinSamples),
+		})
+	}
+	return
+}
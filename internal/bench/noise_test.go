@@ -0,0 +1,50 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckNoise(t *testing.T) {
+	convey.Convey("Given results of Benchmark", t, func() {
+		stable := newRuns("BenchmarkFib/10", 100, 101, 99, 100, 100)
+		noisy := newRuns("BenchmarkSum", 100, 150, 60, 120, 90)
+		for idx := range stable {
+			stable[idx].Runs = 10000
+			noisy[idx].Runs = 10000
+		}
+		pool := newRuns("BenchmarkPool", 5000)
+		pool[0].Runs = 3
+		sets := []Set{{Pkg: "demo", Targets: map[string]BenchmarkList{"Fib": stable, "Sum": noisy, "Pool": pool}}}
+
+		convey.Convey("Warn on statistically weak results", func() {
+			convey.So(CheckNoise(sets, DefaultNoiseOptions), convey.ShouldEqual, 2)
+			convey.So(sets[0].Targets["Fib"][0].Warnings, convey.ShouldBeEmpty)
+
+			warnings := sets[0].Targets["Sum"][3].Warnings
+			convey.So(warnings, convey.ShouldHaveLength, 1)
+			convey.So(warnings[0].Kind, convey.ShouldEqual, WarningHighVariance)
+
+			warnings = sets[0].Targets["Pool"][0].Warnings
+			convey.So(warnings, convey.ShouldHaveLength, 2)
+			convey.So(warnings[0].Kind, convey.ShouldEqual, WarningLowIterations)
+			convey.So(warnings[0].Message, convey.ShouldStartWith, "only 3 iterations")
+			convey.So(warnings[1].Kind, convey.ShouldEqual, WarningFewSamples)
+		})
+
+		convey.Convey("Runs are not counted when every Benchmark runs once", func() {
+			sets[0].Targets["Fib"], sets[0].Targets["Sum"] = stable[:1], noisy[:1]
+			convey.So(CheckNoise(sets, DefaultNoiseOptions), convey.ShouldEqual, 1)
+			convey.So(sets[0].Targets["Fib"][0].Warnings, convey.ShouldBeEmpty)
+
+			warnings := sets[0].Targets["Pool"][0].Warnings
+			convey.So(warnings, convey.ShouldHaveLength, 1)
+			convey.So(warnings[0].Kind, convey.ShouldEqual, WarningLowIterations)
+		})
+
+		convey.Convey("Thresholds <= 0 are not checked", func() {
+			convey.So(CheckNoise(sets, NoiseOptions{}), convey.ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package bench
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/stats"
+)
+
+// methods of outlier detection
+const (
+	OutlierNone = "none"
+	OutlierIQR  = "iqr" // Tukey's fences, out of [Q1 - 1.5*IQR, Q3 + 1.5*IQR]
+	OutlierMAD  = "mad" // modified z-score over 3.5
+)
+
+// OutlierMethods supported methods of outlier detection
+var OutlierMethods = []string{OutlierIQR, OutlierMAD, OutlierNone}
+
+// MinOutlierSamples minimal runs of a Benchmark to detect outliers in, fewer runs can not tell an outlier from the rest
+const MinOutlierSamples = 4
+
+// MarkOutliers mark runs of every Benchmark(same package, name and labels, e.g. by -count) which are outliers in any metric,
+// units of the metrics are set to Benchmark.Outliers. a metric without spread among the runs(IQR or MAD is 0, e.g. allocs/op
+// which is the same in most runs) is skipped, as any deviation from it would be an outlier
+//
+//	@param sets []Set
+//	@param method string one of OutlierMethods
+//	@return count int number of runs which are outliers in any metric
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 00:52:10
+func MarkOutliers(sets []Set, method string) (count int, err error) {
+	var detect func(values []float64) []bool
+	switch method {
+	case OutlierIQR:
+		detect = func(values []float64) []bool {
+			if stats.Quantile(values, 0.75) == stats.Quantile(values, 0.25) {
+				return nil
+			}
+			return stats.OutliersIQR(values, 1.5)
+		}
+	case OutlierMAD:
+		detect = func(values []float64) []bool {
+			if stats.MAD(values) == 0 {
+				return nil
+			}
+			return stats.OutliersMAD(values, 3.5)
+		}
+	case OutlierNone:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("[MarkOutliers] unsupported outlier detection method %q, should be one of %v", method, OutlierMethods)
+	}
+
+	for _, set := range sets {
+		// runs of every Benchmark in the set
+		runs := make(map[string][]*Benchmark)
+		for target := range set.Targets {
+			for idx := range set.Targets[target] {
+				benchmark := &set.Targets[target][idx]
+				benchmark.Outliers = nil
+				key := runKey(benchmark)
+				runs[key] = append(runs[key], benchmark)
+			}
+		}
+		for _, benchmarks := range runs {
+			if len(benchmarks) < MinOutlierSamples {
+				continue
+			}
+			for _, unit := range unitsOf(benchmarks) {
+				values := make([]float64, len(benchmarks))
+				for idx, benchmark := range benchmarks {
+					values[idx] = benchmark.Metrics()[unit]
+				}
+				for idx, outlier := range detect(values) {
+					if outlier {
+						benchmarks[idx].Outliers = append(benchmarks[idx].Outliers, unit)
+					}
+				}
+			}
+			for _, benchmark := range benchmarks {
+				if len(benchmark.Outliers) > 0 {
+					count++
+				}
+			}
+		}
+	}
+	return
+}
+
+// DropOutliers remove runs marked as outliers by MarkOutliers, the number of removed runs is added to Set.OutliersDropped.
+// metrics of a run are aggregated together, so a run which is an outlier in one metric is removed from all of them
+//
+//	@param sets []Set
+//	@return dropped int number of removed runs
+//	@author kevineluo
+//	@update 2026-10-19 20:21:36
+func DropOutliers(sets []Set) (dropped int) {
+	for setIdx := range sets {
+		set := &sets[setIdx]
+		for target, benchmarks := range set.Targets {
+			kept := make(BenchmarkList, 0, len(benchmarks))
+			for _, benchmark := range benchmarks {
+				if len(benchmark.Outliers) > 0 {
+					set.OutliersDropped++
+					dropped++
+					continue
+				}
+				kept = append(kept, benchmark)
+			}
+			set.Targets[target] = kept
+		}
+	}
+	return
+}
+
+// runKey identify runs of the same Benchmark by name and labels, e.g. runs of different revisions are different
+func runKey(benchmark *Benchmark) string {
+	labels := make([]string, 0, len(benchmark.Labels))
+	for name, value := range benchmark.Labels {
+		labels = append(labels, name+"="+value)
+	}
+	sort.Strings(labels)
+	return benchmark.Name + "\x00" + strings.Join(labels, "\x00")
+}
+
+// unitsOf sorted units of all metrics reported by the Benchmark
+func unitsOf(benchmarks []*Benchmark) (units []string) {
+	seen := make(map[string]bool)
+	for _, benchmark := range benchmarks {
+		for unit := range benchmark.Metrics() {
+			if benchmark.Reports(unit) && !seen[unit] {
+				seen[unit] = true
+				units = append(units, unit)
+			}
+		}
+	}
+	sort.Strings(units)
+	return
+}
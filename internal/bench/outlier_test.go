@@ -0,0 +1,73 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func newRuns(name string, nsPerOps ...float64) (benchmarks BenchmarkList) {
+	for _, nsPerOp := range nsPerOps {
+		benchmarks = append(benchmarks, Benchmark{Name: name, NsPerOp: nsPerOp, Mem: Mem{AllocsPerOp: 2}})
+	}
+	return
+}
+
+func TestOutliers(t *testing.T) {
+	convey.Convey("Given runs of Benchmark by -count", t, func() {
+		sets := []Set{{Pkg: "demo", Targets: map[string]BenchmarkList{
+			"Fib": newRuns("BenchmarkFib/10", 100, 101, 99, 102, 100, 98, 160, 101),
+			"Sum": newRuns("BenchmarkSum", 10, 30, 20),
+		}}}
+
+		for _, method := range []string{OutlierIQR, OutlierMAD} {
+			convey.Convey("Mark outliers by "+method, func() {
+				count, err := MarkOutliers(sets, method)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(count, convey.ShouldEqual, 1)
+				convey.So(sets[0].Targets["Fib"][6].Outliers, convey.ShouldResemble, []string{"ns/op"})
+				convey.So(sets[0].Targets["Fib"][0].Outliers, convey.ShouldBeEmpty)
+
+				convey.Convey("Drop them", func() {
+					convey.So(DropOutliers(sets), convey.ShouldEqual, 1)
+					convey.So(sets[0].Targets["Fib"], convey.ShouldHaveLength, 7)
+					convey.So(sets[0].Targets["Sum"], convey.ShouldHaveLength, 3)
+					convey.So(sets[0].OutliersDropped, convey.ShouldEqual, 1)
+				})
+			})
+		}
+
+		convey.Convey("Given metrics disagreeing on outliers", func() {
+			fib := sets[0].Targets["Fib"]
+			// allocs/op deviates in a single run without spread, B/op has spread and an outlier of its own
+			fib[2].Mem.AllocsPerOp = 3
+			for idx, bytesPerOp := range []float64{64, 66, 65, 64, 300, 65, 66, 64} {
+				fib[idx].Mem.BytesPerOp = bytesPerOp
+			}
+
+			for _, method := range []string{OutlierIQR, OutlierMAD} {
+				convey.Convey("Mark outliers of every metric by "+method+", metrics without spread are skipped", func() {
+					count, err := MarkOutliers(sets, method)
+					convey.So(err, convey.ShouldBeNil)
+					convey.So(count, convey.ShouldEqual, 2)
+					convey.So(fib[2].Outliers, convey.ShouldBeEmpty)
+					convey.So(fib[4].Outliers, convey.ShouldResemble, []string{"B/op"})
+					convey.So(fib[6].Outliers, convey.ShouldResemble, []string{"ns/op"})
+
+					convey.Convey("Drop runs which are outliers in any metric", func() {
+						convey.So(DropOutliers(sets), convey.ShouldEqual, 2)
+						convey.So(sets[0].Targets["Fib"], convey.ShouldHaveLength, 6)
+					})
+				})
+			}
+		})
+
+		convey.Convey("Fail on unknown method", func() {
+			_, err := MarkOutliers(sets, "zscore")
+			convey.So(err, convey.ShouldNotBeNil)
+			count, err := MarkOutliers(sets, OutlierNone)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(count, convey.ShouldEqual, 0)
+		})
+	})
+}
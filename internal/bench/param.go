@@ -0,0 +1,101 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Param a key=value parameter in Benchmark name, e.g. size=1024 in BenchmarkEncode/size=1024/compress=true,
+// it is numeric when its value can be parsed as a number, and marshaled to JSON as {"value": <raw>, "number": <numeric>}
+// or a string accordingly, the raw value is kept as numbers lose their form(e.g. 05 or 1.50)
+//
+//	@author kevineluo
+//	@update 2026-10-19 23:41:08
+type Param struct {
+	Value    string  // raw value
+	Number   float64 // numeric value, only valid when IsNumber
+	IsNumber bool
+}
+
+// numericParam JSON form of a numeric Param
+type numericParam struct {
+	Value  string  `json:"value"`
+	Number float64 `json:"number"`
+}
+
+// NewParam parse a parameter value, numeric where possible
+//
+//	@param value string
+//	@return Param
+//	@author kevineluo
+//	@update 2026-10-19 18:05:12
+func NewParam(value string) Param {
+	number, err := strconv.ParseFloat(value, 64)
+	// Inf and NaN are not numbers in JSON
+	return Param{Value: value, Number: number, IsNumber: err == nil && !math.IsInf(number, 0) && !math.IsNaN(number)}
+}
+
+// MarshalJSON implement json.Marshaler, numeric parameter is marshaled as its raw value with the number
+//
+//	@receiver p Param
+//	@return []byte
+//	@return error
+//	@author kevineluo
+//	@update 2026-10-19 23:41:08
+func (p Param) MarshalJSON() ([]byte, error) {
+	if p.IsNumber {
+		return json.Marshal(numericParam{Value: p.Value, Number: p.Number})
+	}
+	return json.Marshal(p.Value)
+}
+
+// UnmarshalJSON implement json.Unmarshaler, accept the object of a numeric parameter or a string
+//
+//	@receiver p *Param
+//	@param data []byte
+//	@return error
+//	@author kevineluo
+//	@update 2026-10-20 02:06:44
+func (p *Param) UnmarshalJSON(data []byte) error {
+	switch {
+	case strings.HasPrefix(string(data), `"`):
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*p = Param{Value: value}
+	case strings.HasPrefix(string(data), "{"):
+		var numeric numericParam
+		if err := json.Unmarshal(data, &numeric); err != nil {
+			return err
+		}
+		*p = Param{Value: numeric.Value, Number: numeric.Number, IsNumber: true}
+	default:
+		return fmt.Errorf("[Param.UnmarshalJSON] parameter should be a string or an object of value and number, got %s", data)
+	}
+	return nil
+}
+
+// ParseParams parse key=value segments of a Benchmark name into parameters, segments are separated by '/',
+// the first segment(the Benchmark function) and segments without '=' are positional and skipped
+//
+//	@param name string
+//	@return params map[string]Param nil when there is no key=value segment
+//	@author kevineluo
+//	@update 2026-10-19 18:05:12
+func ParseParams(name string) (params map[string]Param) {
+	segments := strings.Split(name, "/")
+	for _, segment := range segments[1:] {
+		key, value, found := strings.Cut(segment, "=")
+		if !found || key == "" {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]Param)
+		}
+		params[key] = NewParam(value)
+	}
+	return
+}
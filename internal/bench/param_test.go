@@ -0,0 +1,52 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseParams(t *testing.T) {
+	convey.Convey("Given a Benchmark line with key=value segments", t, func() {
+		benchmark, err := ParseBench("BenchmarkEncode/size=1024/gzip/compress=true-8\t100\t1000 ns/op", "/", nil)
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("Segments with '=' are parsed into typed parameters", func() {
+			convey.So(benchmark.Params, convey.ShouldResemble, map[string]Param{
+				"size":     {Value: "1024", Number: 1024, IsNumber: true},
+				"compress": {Value: "true"},
+			})
+			convey.So(benchmark.Dimension("size"), convey.ShouldEqual, "1024")
+			// positional segments are left to the separator / regexp
+			convey.So(benchmark.Target, convey.ShouldEqual, "Encode")
+			convey.So(benchmark.Scenario, convey.ShouldEqual, "size=1024/gzip/compress=true")
+		})
+
+		convey.Convey("Parameters are marshaled with numbers where possible", func() {
+			data, err := json.Marshal(benchmark.Params)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(data), convey.ShouldEqual, `{"compress":"true","size":{"value":"1024","number":1024}}`)
+
+			var params map[string]Param
+			convey.So(json.Unmarshal(data, &params), convey.ShouldBeNil)
+			convey.So(params, convey.ShouldResemble, benchmark.Params)
+		})
+
+		convey.Convey("Raw values of numeric parameters survive a JSON round trip", func() {
+			params := ParseParams("BenchmarkEncode/size=05/ratio=1.50")
+			data, err := json.Marshal(params)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(data), convey.ShouldEqual, `{"ratio":{"value":"1.50","number":1.5},"size":{"value":"05","number":5}}`)
+
+			var decoded map[string]Param
+			convey.So(json.Unmarshal(data, &decoded), convey.ShouldBeNil)
+			convey.So(decoded, convey.ShouldResemble, params)
+			convey.So(json.Unmarshal([]byte(`{"size":1024}`), &decoded), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("Names without key=value segment have no parameter", func() {
+			convey.So(ParseParams("BenchmarkFib/10"), convey.ShouldBeNil)
+			convey.So(ParseParams("BenchmarkFib=10"), convey.ShouldBeNil)
+		})
+	})
+}
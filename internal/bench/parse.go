@@ -2,6 +2,7 @@ package bench
 
 import (
 	"bufio"
+	"context"
 	"io"
 
 	"github.com/dlclark/regexp2"
@@ -11,32 +12,53 @@ import (
 //
 //	@param reader *bufio.Reader
 //	@param sep string sep of a Benchmark string's target and scenario
+//	@param opts ...ScanOption
 //	@return []Set Sets of structured benchmark
 //	@return error
 //	@author kevineluo
 //	@update 2023-03-07 01:29:47
-func Parse(reader *bufio.Reader, sep string, regex *regexp2.Regexp) ([]Set, error) {
-	sets := make([]Set, 0)
-	for {
-		beginBytes, err := reader.Peek(4)
+func Parse(reader *bufio.Reader, sep string, regex *regexp2.Regexp, opts ...ScanOption) ([]Set, error) {
+	return parse(context.Background(), reader, sep, regex, nil, opts...)
+}
 
-		beginStr := string(beginBytes)
-		if beginStr == "goos" {
-			set, err := ParseSet(reader, sep, regex)
-			if err != nil {
-				return nil, err
-			}
-			sets = append(sets, *set)
-		}
+// BenchmarkHandler handle a Benchmark once it is parsed, set is the Benchmark set being parsed,
+// whose metadata is ready and Benchmarks are parsed so far
+type BenchmarkHandler func(set *Set, benchmark *Benchmark)
 
-		_, _, err = reader.ReadLine()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
+// Stream parse Golang standard benchmark output like Parse, but emits every Benchmark to onBenchmark
+// as soon as its line is read, instead of waiting for whole sets
+//
+//	@param ctx context.Context parsing stops with ctx.Err() once ctx is done, a reader which is an io.Closer is closed then, see NewScanner
+//	@param reader io.Reader
+//	@param sep string
+//	@param regex *regexp2.Regexp
+//	@param onBenchmark BenchmarkHandler
+//	@param opts ...ScanOption
+//	@return []Set Sets of structured benchmark
+//	@return error
+//	@author kevineluo
+//	@update 2026-10-20 01:55:37
+func Stream(ctx context.Context, reader io.Reader, sep string, regex *regexp2.Regexp, onBenchmark BenchmarkHandler, opts ...ScanOption) ([]Set, error) {
+	return parse(ctx, reader, sep, regex, onBenchmark, opts...)
+}
+
+func parse(ctx context.Context, reader io.Reader, sep string, regex *regexp2.Regexp, onBenchmark BenchmarkHandler, opts ...ScanOption) ([]Set, error) {
+	sets := make([]Set, 0)
+	scanner := NewScanner(ctx, reader, sep, regex, opts...)
+	for scanner.Scan() {
+		event := scanner.Event()
+		switch event.Type {
+		case EventBenchmark:
+			if onBenchmark != nil {
+				onBenchmark(event.Set, event.Benchmark)
+			}
+		case EventSetEnd:
+			sets = append(sets, *event.Set)
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
 	return sets, nil
 }
@@ -2,6 +2,8 @@ package bench
 
 import (
 	"bufio"
+	"context"
+	"io"
 	"strings"
 	"testing"
 
@@ -61,18 +63,18 @@ PASS`,
 			Targets: map[string]BenchmarkList{
 				"Fib": {
 					{
-						Name: "BenchmarkFib/10", Runs: 3033732, NsPerOp: 358, Mem: Mem{BytesPerOp: 16, AllocsPerOp: 1}, Target: "Fib", Scenario: "10",
+						Name: "BenchmarkFib/10", Runs: 3033732, NsPerOp: 358, Mem: Mem{BytesPerOp: 16, AllocsPerOp: 1, Benchmem: true}, Target: "Fib", Scenario: "10",
 					},
 					{
-						Name: "BenchmarkFib/100", Runs: 303373, NsPerOp: 358, Mem: Mem{BytesPerOp: 16, AllocsPerOp: 1}, Target: "Fib", Scenario: "100",
+						Name: "BenchmarkFib/100", Runs: 303373, NsPerOp: 358, Mem: Mem{BytesPerOp: 16, AllocsPerOp: 1, Benchmem: true}, Target: "Fib", Scenario: "100",
 					},
 				},
 				"Pizzas": {
 					{
-						Name: "BenchmarkPizzas/10", Runs: 22866814, NsPerOp: 46.3, CustomMetrics: map[string]float64{"pizzas": 9.00}, Target: "Pizzas", Scenario: "10",
+						Name: "BenchmarkPizzas/10", Runs: 22866814, NsPerOp: 46.3, Mem: Mem{Benchmem: true}, CustomMetrics: map[string]float64{"pizzas": 9.00}, Target: "Pizzas", Scenario: "10",
 					},
 					{
-						Name: "BenchmarkPizzas/100", Runs: 2286681, NsPerOp: 46.3, CustomMetrics: map[string]float64{"pizzas": 9.00}, Target: "Pizzas", Scenario: "100",
+						Name: "BenchmarkPizzas/100", Runs: 2286681, NsPerOp: 46.3, Mem: Mem{Benchmem: true}, CustomMetrics: map[string]float64{"pizzas": 9.00}, Target: "Pizzas", Scenario: "100",
 					},
 				},
 			},
@@ -111,3 +113,47 @@ func TestParse(t *testing.T) {
 		})
 	})
 }
+
+func TestStream(t *testing.T) {
+	convey.Convey("Given Golang standard Benchmark output", t, func() {
+		convey.Convey("Stream it", func() {
+			var names []string
+			sets, err := Stream(context.Background(), strings.NewReader(benchmarkOutputs[1]), "/", nil, func(set *Set, benchmark *Benchmark) {
+				// metadata is ready before Benchmark lines
+				convey.So(set.Pkg, convey.ShouldEqual, "go.bobheadxi.dev/gobenchdata/demo")
+				names = append(names, benchmark.Name)
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(sets, convey.ShouldResemble, []Set{targetSets[1]})
+			convey.So(names, convey.ShouldResemble, []string{"BenchmarkFib/10", "BenchmarkFib/100", "BenchmarkPizzas/10", "BenchmarkPizzas/100"})
+		})
+		convey.Convey("Stop streaming a stalled input once ctx is done", func() {
+			pipeReader, pipeWriter := io.Pipe()
+			defer pipeWriter.Close()
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				_, _ = io.WriteString(pipeWriter, "pkg: demo\nBenchmarkFib/10-16\t3033732\t358 ns/op\n")
+				cancel()
+			}()
+			sets, err := Stream(ctx, pipeReader, "/", nil, nil)
+			convey.So(err, convey.ShouldEqual, context.Canceled)
+			convey.So(sets, convey.ShouldBeNil)
+		})
+	})
+}
+
+func TestParseBenchLabels(t *testing.T) {
+	convey.Convey("Given a regexp with extra named groups", t, func() {
+		regex := regexp2.MustCompile(`^Bench(mark)?(?<workload>[^/]+)/(?<target>[^/]+)/(?<scenario>[^/]+)$`, 0)
+		convey.Convey("Other named groups are kept as labels", func() {
+			benchmark, err := ParseBench("BenchmarkAllRandFloat64/Pond-Eager/1u-1Mt-16\t3\t567339057 ns/op", "", regex)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(benchmark.Target, convey.ShouldEqual, "Pond-Eager")
+			convey.So(benchmark.Scenario, convey.ShouldEqual, "1u-1Mt")
+			convey.So(benchmark.Labels, convey.ShouldResemble, map[string]string{"workload": "AllRandFloat64"})
+			convey.So(benchmark.Dimension("workload"), convey.ShouldEqual, "AllRandFloat64")
+			convey.So(benchmark.Dimension("target"), convey.ShouldEqual, "Pond-Eager")
+			convey.So(benchmark.Dimension("unknown"), convey.ShouldBeEmpty)
+		})
+	})
+}
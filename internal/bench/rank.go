@@ -0,0 +1,194 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/stats"
+)
+
+// DefaultRankWeights default weights of metrics in the overall ranking, MB/s is left out as it mirrors ns/op
+var DefaultRankWeights = map[string]float64{"ns/op": 1, "B/op": 1, "allocs/op": 1}
+
+// Ranking leaderboard of targets of a Set, computed by Rank
+type Ranking struct {
+	Scenarios []ScenarioRanking  `json:"scenarios"`
+	Overall   []OverallRank      `json:"overall"`
+	Weights   map[string]float64 `json:"weights"` // weights of metrics in the overall ranking
+}
+
+// ScenarioRanking targets of a scenario ranked by a metric, best first
+type ScenarioRanking struct {
+	Scenario string            `json:"scenario"`
+	Labels   map[string]string `json:"labels,omitempty"` // labels of Benchmark, scenarios with different labels are ranked apart
+	Unit     string            `json:"unit"`
+	Ranks    []TargetRank      `json:"ranks"`
+}
+
+// TargetRank a target in a ScenarioRanking
+type TargetRank struct {
+	Target string  `json:"target"`
+	Value  float64 `json:"value"` // mean of runs
+	// Gap relative gap to the best, e.g. 0.25 for 25% worse, null when the best is 0 and the target is not
+	Gap *float64 `json:"gap"`
+}
+
+// OverallRank a target in the overall ranking across scenarios
+type OverallRank struct {
+	Target string `json:"target"`
+	// Score weighted geometric mean of ratios to the best target(>= 1, the lower the better) in every scenario and metric
+	// ranking all targets, 1 means the best everywhere
+	Score    float64 `json:"score"`
+	Gap      float64 `json:"gap"`      // relative gap of the score to the best score
+	Rankings int     `json:"rankings"` // number of (scenario, metric) rankings weighted in the score, the same for every target
+}
+
+// Rank rank targets in every scenario(with the same labels) by every metric reported, and overall across scenarios
+// by geometric mean of ratios to the best target weighted by metric, rankings are set to Set.Ranking.
+// only scenarios where all targets are present count in the overall ranking, so a target is never favoured by missing
+// a scenario it would lose, and there is no overall ranking when no scenario has all targets.
+// a target not reporting a metric(e.g. run without -benchmem) is left out of the ranking by it rather than taken as 0.
+// a metric whose best value is 0(e.g. allocs/op) is compared with 1 added to both sides, e.g. 2 allocs/op against 0 is 3x,
+// which weighs a lot for metrics of large values, e.g. 8 B/op against 0 is 9x, lower the weight of such metrics if unwanted
+//
+//	@param sets []Set
+//	@param weights map[string]float64 weights of metrics by unit, metrics without weight are left out of the overall ranking
+//	@author kevineluo
+//	@update 2026-10-20 03:14:27
+func Rank(sets []Set, weights map[string]float64) {
+	for idx := range sets {
+		sets[idx].Ranking = rankSet(&sets[idx], weights)
+	}
+}
+
+// rankSet rank targets of a set, nil for a set without Benchmark
+func rankSet(set *Set, weights map[string]float64) (ranking *Ranking) {
+	// runs of every target by scenario and labels
+	type group struct {
+		scenario string
+		labels   map[string]string
+		runs     map[string][]*Benchmark
+		all      []*Benchmark
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for target := range set.Targets {
+		for idx := range set.Targets[target] {
+			benchmark := &set.Targets[target][idx]
+			key := scenarioKey(benchmark)
+			if groups[key] == nil {
+				groups[key] = &group{scenario: benchmark.Scenario, labels: benchmark.Labels, runs: make(map[string][]*Benchmark)}
+				keys = append(keys, key)
+			}
+			groups[key].runs[benchmark.Target] = append(groups[key].runs[benchmark.Target], benchmark)
+			groups[key].all = append(groups[key].all, benchmark)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+
+	ranking = &Ranking{Weights: weights}
+	// ratios to the best of targets in every weighted ranking
+	type weighted struct {
+		weight float64
+		ratios map[string]float64
+	}
+	var weightedRankings []weighted
+	targets := make(map[string]bool)
+	for _, key := range keys {
+		for _, unit := range unitsOf(groups[key].all) {
+			scenarioRanking := ScenarioRanking{Scenario: groups[key].scenario, Labels: groups[key].labels, Unit: unit}
+			for target, runs := range groups[key].runs {
+				values := make([]float64, 0, len(runs))
+				for _, benchmark := range runs {
+					if benchmark.Reports(unit) {
+						values = append(values, benchmark.Metrics()[unit])
+					}
+				}
+				// a target not reporting the unit is left out rather than ranked as 0
+				if len(values) > 0 {
+					scenarioRanking.Ranks = append(scenarioRanking.Ranks, TargetRank{Target: target, Value: stats.Mean(values)})
+				}
+			}
+			higherIsBetter := HigherIsBetter(unit)
+			sort.Slice(scenarioRanking.Ranks, func(i, j int) bool {
+				if a, b := scenarioRanking.Ranks[i].Value, scenarioRanking.Ranks[j].Value; a != b {
+					return (a < b) != higherIsBetter
+				}
+				return scenarioRanking.Ranks[i].Target < scenarioRanking.Ranks[j].Target
+			})
+
+			best := scenarioRanking.Ranks[0].Value
+			weight := weights[unit]
+			if weight > 0 {
+				weightedRankings = append(weightedRankings, weighted{weight: weight, ratios: make(map[string]float64)})
+			}
+			for idx := range scenarioRanking.Ranks {
+				rank := &scenarioRanking.Ranks[idx]
+				if best != 0 {
+					gap := math.Abs(rank.Value-best) / math.Abs(best)
+					rank.Gap = &gap
+				} else if rank.Value == 0 {
+					rank.Gap = new(float64)
+				}
+				if weight > 0 {
+					weightedRankings[len(weightedRankings)-1].ratios[rank.Target] = ratioToBest(rank.Value, best, higherIsBetter)
+					targets[rank.Target] = true
+				}
+			}
+			ranking.Scenarios = append(ranking.Scenarios, scenarioRanking)
+		}
+	}
+
+	logRatios, weightSum, count := make(map[string]float64), 0.0, 0
+	for _, weightedRanking := range weightedRankings {
+		if len(weightedRanking.ratios) < len(targets) {
+			// some target is missing in the scenario
+			continue
+		}
+		for target, ratio := range weightedRanking.ratios {
+			logRatios[target] += weightedRanking.weight * math.Log(ratio)
+		}
+		weightSum += weightedRanking.weight
+		count++
+	}
+	for target, logRatio := range logRatios {
+		ranking.Overall = append(ranking.Overall, OverallRank{Target: target, Score: math.Exp(logRatio / weightSum), Rankings: count})
+	}
+	sort.Slice(ranking.Overall, func(i, j int) bool {
+		if ranking.Overall[i].Score != ranking.Overall[j].Score {
+			return ranking.Overall[i].Score < ranking.Overall[j].Score
+		}
+		return ranking.Overall[i].Target < ranking.Overall[j].Target
+	})
+	for idx := range ranking.Overall {
+		ranking.Overall[idx].Gap = ranking.Overall[idx].Score/ranking.Overall[0].Score - 1
+	}
+	return ranking
+}
+
+// ratioToBest ratio of value to the best value(>= 1), 1 is added to both sides when divided by 0
+func ratioToBest(value, best float64, higherIsBetter bool) float64 {
+	worse, better := value, best
+	if higherIsBetter {
+		worse, better = best, value
+	}
+	if better == 0 {
+		return (worse + 1) / (better + 1)
+	}
+	return worse / better
+}
+
+// scenarioKey scenario of a Benchmark with its labels
+func scenarioKey(benchmark *Benchmark) string {
+	labels := make([]string, 0, len(benchmark.Labels))
+	for name, value := range benchmark.Labels {
+		labels = append(labels, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(labels)
+	return strings.Join(append([]string{benchmark.Scenario}, labels...), "\x00")
+}
@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"math"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestRank(t *testing.T) {
+	convey.Convey("Given Benchmark of pools in scenarios", t, func() {
+		sets := []Set{{Pkg: "demo", Targets: map[string]BenchmarkList{
+			"Goroutines": {
+				{Target: "Goroutines", Scenario: "1K", NsPerOp: 200, Mem: Mem{Benchmem: true}},
+				{Target: "Goroutines", Scenario: "1M", NsPerOp: 400, Mem: Mem{Benchmem: true}},
+			},
+			"Ants": {
+				{Target: "Ants", Scenario: "1K", NsPerOp: 100, Mem: Mem{AllocsPerOp: 2, Benchmem: true}},
+				{Target: "Ants", Scenario: "1K", NsPerOp: 300, Mem: Mem{AllocsPerOp: 2, Benchmem: true}},
+				{Target: "Ants", Scenario: "1M", NsPerOp: 100, Mem: Mem{AllocsPerOp: 1, Benchmem: true}},
+			},
+		}}, {Pkg: "empty"}}
+
+		convey.Convey("Targets are ranked in every scenario and metric", func() {
+			Rank(sets, map[string]float64{"ns/op": 1})
+			convey.So(sets[1].Ranking, convey.ShouldBeNil)
+			ranking := sets[0].Ranking
+			convey.So(ranking.Scenarios, convey.ShouldHaveLength, 6)
+
+			// the mean of runs, tied values by target
+			convey.So(ranking.Scenarios[2].Scenario, convey.ShouldEqual, "1K")
+			convey.So(ranking.Scenarios[2].Unit, convey.ShouldEqual, "ns/op")
+			ranks := ranking.Scenarios[2].Ranks
+			convey.So(ranks[0].Target, convey.ShouldEqual, "Ants")
+			convey.So(*ranks[0].Gap, convey.ShouldEqual, 0)
+			convey.So(ranks[1].Target, convey.ShouldEqual, "Goroutines")
+			convey.So(*ranks[1].Gap, convey.ShouldEqual, 0)
+
+			convey.So(ranking.Scenarios[5].Scenario, convey.ShouldEqual, "1M")
+			ranks = ranking.Scenarios[5].Ranks
+			convey.So(ranks[0].Target, convey.ShouldEqual, "Ants")
+			convey.So(ranks[1].Value, convey.ShouldEqual, 400)
+			convey.So(*ranks[1].Gap, convey.ShouldEqual, 3)
+
+			// no relative gap to a zero best
+			convey.So(ranking.Scenarios[4].Unit, convey.ShouldEqual, "allocs/op")
+			ranks = ranking.Scenarios[4].Ranks
+			convey.So(ranks[0].Target, convey.ShouldEqual, "Goroutines")
+			convey.So(*ranks[0].Gap, convey.ShouldEqual, 0)
+			convey.So(ranks[1].Gap, convey.ShouldBeNil)
+		})
+
+		convey.Convey("Overall ranking is the weighted geometric mean of ratios to the best", func() {
+			Rank(sets, map[string]float64{"ns/op": 1})
+			overall := sets[0].Ranking.Overall
+			convey.So(overall, convey.ShouldHaveLength, 2)
+			convey.So(overall[0].Target, convey.ShouldEqual, "Ants")
+			convey.So(overall[0].Score, convey.ShouldEqual, 1)
+			convey.So(overall[0].Rankings, convey.ShouldEqual, 2)
+			// sqrt(1 * 4)
+			convey.So(overall[1].Score, convey.ShouldAlmostEqual, 2, 1e-12)
+			convey.So(overall[1].Gap, convey.ShouldAlmostEqual, 1, 1e-12)
+
+			// 1 is added to both sides of zero allocs/op, 3x and 2x for Ants, (3 * 2)^(3 / 8)
+			Rank(sets, map[string]float64{"ns/op": 1, "allocs/op": 3})
+			overall = sets[0].Ranking.Overall
+			convey.So(overall[0].Target, convey.ShouldEqual, "Goroutines")
+			convey.So(overall[1].Target, convey.ShouldEqual, "Ants")
+			convey.So(overall[1].Score, convey.ShouldAlmostEqual, math.Pow(6, 3.0/8), 1e-9)
+			convey.So(overall[1].Rankings, convey.ShouldEqual, 4)
+
+			// 8 B/op against 0 is 9x, sqrt(1 * 9)
+			sets[0].Targets["Ants"][2].Mem.BytesPerOp = 8
+			Rank(sets, map[string]float64{"B/op": 1})
+			overall = sets[0].Ranking.Overall
+			convey.So(overall[1].Target, convey.ShouldEqual, "Ants")
+			convey.So(overall[1].Score, convey.ShouldAlmostEqual, 3, 1e-9)
+			convey.So(overall[1].Rankings, convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("Only scenarios of all targets count in the overall ranking", func() {
+			// Pond is the best in 1K, but missing in 1M where Ants is 4x better than Goroutines
+			sets[0].Targets["Pond"] = BenchmarkList{{Target: "Pond", Scenario: "1K", NsPerOp: 50}}
+			Rank(sets, map[string]float64{"ns/op": 1})
+			overall := sets[0].Ranking.Overall
+			convey.So(overall, convey.ShouldHaveLength, 3)
+			convey.So(overall[0].Target, convey.ShouldEqual, "Pond")
+			convey.So(overall[0].Score, convey.ShouldEqual, 1)
+			convey.So(overall[0].Rankings, convey.ShouldEqual, 1)
+			convey.So(overall[1].Target, convey.ShouldEqual, "Ants")
+			convey.So(overall[1].Score, convey.ShouldAlmostEqual, 4, 1e-9)
+			convey.So(overall[2].Target, convey.ShouldEqual, "Goroutines")
+			convey.So(overall[2].Score, convey.ShouldAlmostEqual, 4, 1e-9)
+
+			// no scenario has all targets
+			sets[0].Targets["Pond"][0].Scenario = "1G"
+			Rank(sets, map[string]float64{"ns/op": 1})
+			convey.So(sets[0].Ranking.Overall, convey.ShouldBeEmpty)
+			convey.So(sets[0].Ranking.Scenarios, convey.ShouldHaveLength, 7)
+		})
+
+		convey.Convey("Targets not reporting a metric are left out of its rankings", func() {
+			// Goroutines run without -benchmem, its allocs/op is unknown rather than 0
+			for idx := range sets[0].Targets["Goroutines"] {
+				sets[0].Targets["Goroutines"][idx].Mem.Benchmem = false
+			}
+			Rank(sets, map[string]float64{"ns/op": 1, "allocs/op": 3})
+			ranking := sets[0].Ranking
+			convey.So(ranking.Scenarios[1].Unit, convey.ShouldEqual, "allocs/op")
+			convey.So(ranking.Scenarios[1].Ranks, convey.ShouldHaveLength, 1)
+			convey.So(ranking.Scenarios[1].Ranks[0].Target, convey.ShouldEqual, "Ants")
+
+			// allocs/op rankings lack Goroutines, only ns/op counts
+			convey.So(ranking.Overall[0].Target, convey.ShouldEqual, "Ants")
+			convey.So(ranking.Overall[0].Rankings, convey.ShouldEqual, 2)
+			convey.So(ranking.Overall[1].Score, convey.ShouldAlmostEqual, 2, 1e-12)
+		})
+	})
+}
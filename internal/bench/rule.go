@@ -0,0 +1,95 @@
+package bench
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/dlclark/regexp2"
+)
+
+// NameRule a rule to split Benchmark name into target and scenario by a separator or a regexp,
+// optionally scoped to Benchmark sets whose package matches Pkg
+//
+//	@author kevineluo
+//	@update 2026-10-19 16:52:14
+type NameRule struct {
+	Pkg   *regexp.Regexp  // pattern of package path, nil for all packages
+	Sep   string          // separator, used when Regex is nil
+	Regex *regexp2.Regexp // regexp with 'target' and 'scenario' groups
+}
+
+// Split split a Benchmark name by the rule
+//
+//	@receiver rule NameRule
+//	@param name string
+//	@return target string
+//	@return scenario string
+//	@return labels map[string]string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 17:41:50
+func (rule NameRule) Split(name string) (target, scenario string, labels map[string]string, err error) {
+	return SplitName(name, rule.Sep, rule.Regex)
+}
+
+// ScanOption option of Scanner
+type ScanOption func(scanner *Scanner)
+
+// WithNameRules add rules tried in order before the separator / regexp given to the Scanner,
+// the first rule of the package which splits the Benchmark name wins
+//
+//	@param rules ...NameRule
+//	@return ScanOption
+//	@author kevineluo
+//	@update 2026-10-19 16:52:14
+func WithNameRules(rules ...NameRule) ScanOption {
+	return func(scanner *Scanner) {
+		scanner.nameRules = append(scanner.nameRules, rules...)
+	}
+}
+
+// WithCatchAll put the whole Benchmark name into target with an empty scenario,
+// when the name can not be split by any rule, instead of failing the scan
+//
+//	@return ScanOption
+//	@author kevineluo
+//	@update 2026-10-19 16:52:14
+func WithCatchAll() ScanOption {
+	return func(scanner *Scanner) {
+		scanner.catchAll = true
+	}
+}
+
+// WithAutoSplit infer the regexp to split Benchmark names from all names of a set, it replaces the separator / regexp
+// given to the Scanner unless inference fails, Benchmark events of a set are emitted together at the end of the set
+//
+//	@return ScanOption
+//	@author kevineluo
+//	@update 2026-10-19 17:20:36
+func WithAutoSplit() ScanOption {
+	return func(scanner *Scanner) {
+		scanner.auto = true
+	}
+}
+
+// splitName split a Benchmark name of the given package by name rules, then the separator / regexp,
+// then the catch-all rule if it is enabled, errors of all rules are returned when none of them splits the name
+func (s *Scanner) splitName(pkg string, name string, sep string, regex *regexp2.Regexp) (target, scenario string, labels map[string]string, err error) {
+	var errs []error
+	for _, rule := range s.nameRules {
+		if rule.Pkg != nil && !rule.Pkg.MatchString(pkg) {
+			continue
+		}
+		if target, scenario, labels, err = rule.Split(name); err == nil {
+			return
+		}
+		errs = append(errs, err)
+	}
+	if target, scenario, labels, err = SplitName(name, sep, regex); err == nil {
+		return
+	}
+	if s.catchAll {
+		return name, "", nil, nil
+	}
+	return "", "", nil, errors.Join(append(errs, err)...)
+}
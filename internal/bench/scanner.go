@@ -0,0 +1,308 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/dlclark/regexp2"
+)
+
+// EventType type of event emitted by Scanner
+type EventType int
+
+const (
+	// EventSetStart a Benchmark set begins, emitted on its 'goos' line
+	EventSetStart EventType = iota
+	// EventMetadata a config line of the set(goos, goarch, pkg, cpu...) is parsed
+	EventMetadata
+	// EventBenchmark a Benchmark line is parsed
+	EventBenchmark
+	// EventSetEnd a Benchmark set ends with 'PASS' or 'FAIL', the set is complete
+	EventSetEnd
+)
+
+// String name of event type
+//
+//	@receiver eventType EventType
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 14:58:40
+func (eventType EventType) String() string {
+	switch eventType {
+	case EventSetStart:
+		return "set-start"
+	case EventMetadata:
+		return "metadata"
+	case EventBenchmark:
+		return "benchmark"
+	case EventSetEnd:
+		return "set-end"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(eventType))
+	}
+}
+
+// Event an event emitted by Scanner
+type Event struct {
+	Type EventType
+	// Set the set being parsed, its metadata and Benchmarks are the ones parsed so far,
+	// it is complete on EventSetEnd and must not be retained after the next Scan if it will be modified
+	Set *Set
+	// Key and Value of the config line, only for EventMetadata
+	Key   string
+	Value string
+	// Benchmark parsed Benchmark, only for EventBenchmark
+	Benchmark *Benchmark
+}
+
+// metadataSetters config lines recognized in a Benchmark set, the last four are not emitted by go test but by Write,
+// so they are only recognized in the header of a set(before its first Benchmark line), where Write puts them,
+// lest logs of Benchmarks(e.g. 'go: downloading ...') overwrite them
+var metadataSetters = map[string]func(set *Set, value string){
+	"goos":    func(set *Set, value string) { set.Goos = value },
+	"goarch":  func(set *Set, value string) { set.Goarch = value },
+	"pkg":     func(set *Set, value string) { set.Pkg = value },
+	"cpu":     func(set *Set, value string) { set.CPU = value },
+	"command": func(set *Set, value string) { set.Command = value },
+	"go":      func(set *Set, value string) { set.GoVersion = value },
+	"commit":  func(set *Set, value string) { set.Commit = value },
+	"date":    func(set *Set, value string) { set.Date = value },
+}
+
+// headerOnlyMetadata config lines only recognized in the header of a Benchmark set
+var headerOnlyMetadata = map[string]bool{"command": true, "go": true, "commit": true, "date": true}
+
+// Scanner scan Golang standard benchmark output line by line and emit events as soon as lines are read,
+// like bufio.Scanner, successive calls to Scan step through the events.
+// Only the set being parsed is held in memory, so memory use is bounded by the largest set
+//
+//	scanner := bench.NewScanner(ctx, reader, "/", nil)
+//	for scanner.Scan() {
+//		event := scanner.Event()
+//		...
+//	}
+//	if err := scanner.Err(); err != nil {
+//		...
+//	}
+//
+//	@author kevineluo
+//	@update 2026-10-19 14:58:40
+type Scanner struct {
+	ctx    context.Context
+	reader *bufio.Reader
+	sep    string
+	regex  *regexp2.Regexp
+
+	nameRules []NameRule
+	catchAll  bool
+	auto      bool
+	autoLines []string // Benchmark lines of the set being parsed, split at the end of the set in auto mode
+
+	set      *Set // set being parsed, nil when outside of a set
+	inBody   bool // a Benchmark line of the set being parsed has been read, the header of the set is over
+	pending  []Event
+	event    Event
+	err      error
+	done     bool
+	finished chan struct{} // closed once the scan stops, to release the watch of ctx
+}
+
+// NewScanner create a Scanner reading from reader, Benchmark names are split by sep or regex like ParseBench
+//
+//	@param ctx context.Context Scan stops with ctx.Err() once ctx is done, ctx is checked between lines,
+//		so a read blocked on a stalled input(e.g. a pipe) is only interrupted when reader is an io.Closer, which is closed then
+//	@param reader io.Reader
+//	@param sep string
+//	@param regex *regexp2.Regexp
+//	@param opts ...ScanOption
+//	@return scanner *Scanner
+//	@author kevineluo
+//	@update 2026-10-19 22:52:31
+func NewScanner(ctx context.Context, reader io.Reader, sep string, regex *regexp2.Regexp, opts ...ScanOption) (scanner *Scanner) {
+	scanner = &Scanner{
+		ctx:    ctx,
+		reader: bufio.NewReader(reader),
+		sep:    sep,
+		regex:  regex,
+	}
+	for _, opt := range opts {
+		opt(scanner)
+	}
+	if closer, ok := reader.(io.Closer); ok && ctx.Done() != nil {
+		scanner.finished = make(chan struct{})
+		go func(finished chan struct{}) {
+			select {
+			case <-ctx.Done():
+				_ = closer.Close()
+			case <-finished:
+			}
+		}(scanner.finished)
+	}
+	return
+}
+
+// Scan advance to the next event, it returns false when the scan stops, either by reaching the end of input or an error
+//
+//	@receiver s *Scanner
+//	@return bool
+//	@author kevineluo
+//	@update 2026-10-19 14:58:40
+func (s *Scanner) Scan() bool {
+	for len(s.pending) == 0 {
+		if s.done {
+			return false
+		}
+		if err := s.ctx.Err(); err != nil {
+			s.stop(err)
+			return false
+		}
+		line, err := s.reader.ReadString('\n')
+		if line != "" {
+			if parseErr := s.scanLine(strings.TrimRight(line, "\r\n")); parseErr != nil {
+				s.stop(parseErr)
+				return false
+			}
+		}
+		if err == io.EOF {
+			if s.set != nil {
+				s.stop(fmt.Errorf("found EOF before 'PASS' or 'FAIL'(the end of a Benchmark set)"))
+				return false
+			}
+			s.finish()
+		} else if err != nil {
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				// the read is interrupted by closing the reader
+				err = ctxErr
+			}
+			s.stop(err)
+			return false
+		}
+	}
+	s.event, s.pending = s.pending[0], s.pending[1:]
+	return true
+}
+
+// Event the most recent event generated by Scan
+//
+//	@receiver s *Scanner
+//	@return Event
+//	@author kevineluo
+//	@update 2026-10-19 14:58:40
+func (s *Scanner) Event() Event {
+	return s.event
+}
+
+// Err the first error encountered by the Scanner, nil when the input ends normally
+//
+//	@receiver s *Scanner
+//	@return error
+//	@author kevineluo
+//	@update 2026-10-19 14:58:40
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// scanLine parse a line and queue events of it
+func (s *Scanner) scanLine(line string) (err error) {
+	if s.set == nil {
+		// outside of a set, skip everything until a set begins
+		if !strings.HasPrefix(line, "goos") {
+			return
+		}
+		s.set, s.inBody = &Set{Targets: make(map[string]BenchmarkList)}, false
+		s.pending = append(s.pending, Event{Type: EventSetStart, Set: s.set})
+	}
+
+	if strings.HasPrefix(line, "PASS") || strings.HasPrefix(line, "FAIL") {
+		// end of one set
+		if s.auto {
+			if err = s.flushAuto(); err != nil {
+				return
+			}
+		}
+		log.Info("Benchmark set parsed")
+		s.pending = append(s.pending, Event{Type: EventSetEnd, Set: s.set})
+		s.set = nil
+	} else if strings.HasPrefix(line, "Bench") {
+		log.Debug("[Scanner] Benchmark line", "origin_line", line)
+		s.inBody = true
+		if s.auto {
+			// names are split once all of them in the set are known
+			s.autoLines = append(s.autoLines, line)
+			return
+		}
+		return s.addBenchmark(line, s.sep, s.regex)
+	} else if key, value, found := strings.Cut(line, ": "); found {
+		if setter, ok := metadataSetters[key]; ok && !(s.inBody && headerOnlyMetadata[key]) {
+			setter(s.set, value)
+			log.Info("Benchmark metadata", key, value)
+			s.pending = append(s.pending, Event{Type: EventMetadata, Set: s.set, Key: key, Value: value})
+		}
+	}
+	return
+}
+
+// addBenchmark parse a Benchmark line, split its name by name rules then sep / regex, and queue its event
+func (s *Scanner) addBenchmark(line string, sep string, regex *regexp2.Regexp) (err error) {
+	pkg := s.set.Pkg
+	bench, err := parseBench(line, func(name string) (target, scenario string, labels map[string]string, err error) {
+		return s.splitName(pkg, name, sep, regex)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, line)
+	}
+	log.Debug("Benchmark parsed", "name", bench.Name, "runs", bench.Runs, "target", bench.Target, "scenario", bench.Scenario)
+	s.set.Targets[bench.Target] = append(s.set.Targets[bench.Target], *bench)
+	s.pending = append(s.pending, Event{Type: EventBenchmark, Set: s.set, Benchmark: bench})
+	return
+}
+
+// flushAuto infer the regexp of Benchmark names in the set, and add Benchmark lines split by it
+func (s *Scanner) flushAuto() (err error) {
+	lines := s.autoLines
+	s.autoLines = nil
+	names := make([]string, 0, len(lines))
+	for _, line := range lines {
+		bench, err := parseBench(line, func(name string) (string, string, map[string]string, error) { return name, "", nil, nil })
+		if err != nil {
+			return fmt.Errorf("%w: %q", err, line)
+		}
+		names = append(names, bench.Name)
+	}
+
+	sep, regex := s.sep, s.regex
+	if len(names) > 0 {
+		if expr, err := InferNameRegex(names); err != nil {
+			log.Warn("failed to infer the split of Benchmark names, fall back to the given separator / regexp", "pkg", s.set.Pkg, "err", err)
+		} else {
+			log.Info("inferred regexp of Benchmark names, pin it with --regex", "pkg", s.set.Pkg, "regex", expr)
+			sep, regex = "", regexp2.MustCompile(expr, 0)
+		}
+	}
+	for _, line := range lines {
+		if err = s.addBenchmark(line, sep, regex); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// stop stop scanning with an error
+func (s *Scanner) stop(err error) {
+	s.err = err
+	s.pending = nil
+	s.finish()
+}
+
+// finish mark the scan as done and release the watch of ctx
+func (s *Scanner) finish() {
+	s.done = true
+	if s.finished != nil {
+		close(s.finished)
+		s.finished = nil
+	}
+}
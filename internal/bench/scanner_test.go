@@ -0,0 +1,136 @@
+package bench
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dlclark/regexp2"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestScanner(t *testing.T) {
+	convey.Convey("Given Golang standard Benchmark output with back-to-back sets", t, func() {
+		output := benchmarkOutputs[1] + "\n" + benchmarkOutputs[1]
+
+		convey.Convey("Scan events from it", func() {
+			scanner := NewScanner(context.Background(), strings.NewReader(output), "/", nil)
+			var types []string
+			var sets []Set
+			for scanner.Scan() {
+				event := scanner.Event()
+				types = append(types, event.Type.String())
+				if event.Type == EventSetEnd {
+					sets = append(sets, *event.Set)
+				}
+			}
+			convey.So(scanner.Err(), convey.ShouldBeNil)
+			setEvents := []string{"set-start", "metadata", "metadata", "metadata", "benchmark", "benchmark", "benchmark", "benchmark", "set-end"}
+			convey.So(types, convey.ShouldResemble, append(append([]string{}, setEvents...), setEvents...))
+			convey.So(sets, convey.ShouldResemble, []Set{targetSets[1], targetSets[1]})
+		})
+
+		convey.Convey("Scan stops once context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			scanner := NewScanner(ctx, strings.NewReader(output), "/", nil)
+			convey.So(scanner.Scan(), convey.ShouldBeTrue)
+			cancel()
+			for scanner.Scan() {
+			}
+			convey.So(scanner.Err(), convey.ShouldEqual, context.Canceled)
+		})
+
+		convey.Convey("Scan blocked on a stalled pipe stops once context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			reader, writer := io.Pipe()
+			defer writer.Close()
+			scanner := NewScanner(ctx, reader, "/", nil)
+			go func() {
+				_, _ = writer.Write([]byte("goos: linux\n"))
+				cancel()
+			}()
+			for scanner.Scan() {
+			}
+			convey.So(scanner.Err(), convey.ShouldEqual, context.Canceled)
+		})
+
+		convey.Convey("Scan fails on truncated set", func() {
+			scanner := NewScanner(context.Background(), strings.NewReader(strings.TrimSuffix(output, "PASS")), "/", nil)
+			for scanner.Scan() {
+			}
+			convey.So(scanner.Err(), convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("Scan lines longer than the read buffer", func() {
+			longName := "BenchmarkFib/" + strings.Repeat("x", 8192)
+			scanner := NewScanner(context.Background(), strings.NewReader("goos: linux\n"+longName+"\t1\t1 ns/op\nPASS\n"), "/", nil)
+			var benchmark *Benchmark
+			for scanner.Scan() {
+				if event := scanner.Event(); event.Type == EventBenchmark {
+					benchmark = event.Benchmark
+				}
+			}
+			convey.So(scanner.Err(), convey.ShouldBeNil)
+			convey.So(benchmark.Name, convey.ShouldEqual, longName)
+		})
+
+		convey.Convey("Scan metadata of Write only in the header of a set", func() {
+			input := "goos: linux\ngo: go1.22.1\ncommit: abc123\nBenchmarkFib/10\t1\t1 ns/op\n" +
+				"go: downloading example.com/dep v1.0.0\ncommit: logged by a Benchmark\npkg: example.com/fib\nBenchmarkFib/20\t1\t1 ns/op\nPASS\n"
+			scanner := NewScanner(context.Background(), strings.NewReader(input), "/", nil)
+			var set Set
+			for scanner.Scan() {
+				if event := scanner.Event(); event.Type == EventSetEnd {
+					set = *event.Set
+				}
+			}
+			convey.So(scanner.Err(), convey.ShouldBeNil)
+			convey.So(set.GoVersion, convey.ShouldEqual, "go1.22.1")
+			convey.So(set.Commit, convey.ShouldEqual, "abc123")
+			convey.So(set.Pkg, convey.ShouldEqual, "example.com/fib")
+		})
+
+		convey.Convey("Scan with name rules", func() {
+			input := "goos: linux\npkg: example.com/fib\nBenchmarkFib_10\t1\t1 ns/op\nPASS\n" +
+				"goos: linux\npkg: example.com/sleep\nBenchmarkSleep/10ms\t1\t1 ns/op\nPASS\n"
+			rule := NameRule{Pkg: regexp.MustCompile(`/fib$`), Sep: "_"}
+			scanner := NewScanner(context.Background(), strings.NewReader(input), "/", nil, WithNameRules(rule))
+			var benchmarks []Benchmark
+			for scanner.Scan() {
+				if event := scanner.Event(); event.Type == EventBenchmark {
+					benchmarks = append(benchmarks, *event.Benchmark)
+				}
+			}
+			convey.So(scanner.Err(), convey.ShouldBeNil)
+			convey.So(benchmarks, convey.ShouldHaveLength, 2)
+			convey.So([]string{benchmarks[0].Target, benchmarks[0].Scenario}, convey.ShouldResemble, []string{"Fib", "10"})
+			convey.So([]string{benchmarks[1].Target, benchmarks[1].Scenario}, convey.ShouldResemble, []string{"Sleep", "10ms"})
+		})
+
+		convey.Convey("Scan with fallback name rules and catch-all", func() {
+			input := "goos: linux\npkg: example.com/mixed\n" +
+				"BenchmarkFoo/size=10\t1\t1 ns/op\nBenchmarkBar_10\t1\t1 ns/op\nBenchmarkBaz\t1\t1 ns/op\nPASS\n"
+			rules := []NameRule{
+				{Regex: regexp2.MustCompile(`^Benchmark(?<target>\w+)/size=(?<scenario>\d+)$`, 0)},
+				{Sep: "_"},
+			}
+			scan := func(opts ...ScanOption) (splits [][]string, err error) {
+				scanner := NewScanner(context.Background(), strings.NewReader(input), "/", nil, opts...)
+				for scanner.Scan() {
+					if event := scanner.Event(); event.Type == EventBenchmark {
+						splits = append(splits, []string{event.Benchmark.Target, event.Benchmark.Scenario})
+					}
+				}
+				return splits, scanner.Err()
+			}
+
+			_, err := scan(WithNameRules(rules...))
+			convey.So(err, convey.ShouldNotBeNil)
+			splits, err := scan(WithNameRules(rules...), WithCatchAll())
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(splits, convey.ShouldResemble, [][]string{{"Foo", "10"}, {"Bar", "10"}, {"BenchmarkBaz", ""}})
+		})
+	})
+}
@@ -0,0 +1,142 @@
+// Package compare compare samples of Benchmark between two revisions(or any two groups of runs)
+//
+//	@update 2026-10-19 19:58:21
+package compare
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/stats"
+)
+
+// Verdicts of a row
+const (
+	VerdictSame        = "~" // no significant change, like benchstat
+	VerdictRegression  = "regression"
+	VerdictImprovement = "improvement"
+)
+
+// Row comparison of a metric of a Benchmark
+type Row struct {
+	Pkg  string
+	Name string // full name of Benchmark, e.g. BenchmarkFib/10
+	Unit string
+	Old  []float64 // samples of the old revision
+	New  []float64 // samples of the new revision
+	stats.Comparison
+}
+
+// Verdict whether the metric regresses, improves or stays the same(~) at the confidence and minimal relative change
+//
+//	@receiver row Row
+//	@param confidence float64 e.g. 0.95
+//	@param minChange float64 e.g. 0.02 for 2%
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func (row Row) Verdict(confidence, minChange float64) string {
+	if !row.Significant(confidence, minChange) {
+		return VerdictSame
+	}
+	if (row.Change > 0) != bench.HigherIsBetter(row.Unit) {
+		return VerdictRegression
+	}
+	return VerdictImprovement
+}
+
+// Sets compare every metric of every Benchmark(keyed by package and name) of old sets with new sets,
+// runs of a Benchmark(e.g. by -count) are samples, Benchmark missing in either side are left out
+//
+//	@param oldSets []bench.Set
+//	@param newSets []bench.Set
+//	@return rows []Row sorted by package and name, metrics in the order of go test output
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func Sets(oldSets, newSets []bench.Set) (rows []Row) {
+	oldSamples, newSamples := samplesOf(oldSets), samplesOf(newSets)
+	keys := make([][2]string, 0, len(oldSamples))
+	for key := range oldSamples {
+		if _, ok := newSamples[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+
+	for _, key := range keys {
+		oldMetrics, newMetrics := oldSamples[key], newSamples[key]
+		for _, unit := range units(oldMetrics, newMetrics) {
+			row := Row{Pkg: key[0], Name: key[1], Unit: unit, Old: oldMetrics[unit], New: newMetrics[unit]}
+			row.Comparison = stats.Compare(row.Old, row.New)
+			rows = append(rows, row)
+		}
+	}
+	return
+}
+
+// WriteText write rows as an aligned text table, like benchstat
+//
+//	@param w io.Writer
+//	@param rows []Row
+//	@param confidence float64
+//	@param minChange float64
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func WriteText(w io.Writer, rows []Row, confidence, minChange float64) (err error) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "pkg\tname\tunit\told\tnew\tdelta\tp\tn\tverdict")
+	for _, row := range rows {
+		delta := fmt.Sprintf("%+.2f%%", row.Change*100)
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%.3f\t%d+%d\t%s\n", row.Pkg, row.Name, row.Unit,
+			formatFloat(row.Before), formatFloat(row.After), delta, row.P, len(row.Old), len(row.New), row.Verdict(confidence, minChange))
+	}
+	return writer.Flush()
+}
+
+// samplesOf samples of every metric of every Benchmark, keyed by package and name, then unit
+func samplesOf(sets []bench.Set) (samples map[[2]string]map[string][]float64) {
+	samples = make(map[[2]string]map[string][]float64)
+	for _, set := range sets {
+		for _, benchmarks := range set.Targets {
+			for idx := range benchmarks {
+				key := [2]string{set.Pkg, benchmarks[idx].Name}
+				if samples[key] == nil {
+					samples[key] = make(map[string][]float64)
+				}
+				for unit, value := range benchmarks[idx].Metrics() {
+					samples[key][unit] = append(samples[key][unit], value)
+				}
+			}
+		}
+	}
+	return
+}
+
+// units units reported(non-zero) in either side, builtin metrics first, then custom metrics in order
+func units(oldMetrics, newMetrics map[string][]float64) (units []string) {
+	reported := make(map[string]bool)
+	for _, metrics := range []map[string][]float64{oldMetrics, newMetrics} {
+		for unit, values := range metrics {
+			for _, value := range values {
+				reported[unit] = reported[unit] || value != 0
+			}
+		}
+	}
+	return bench.SortUnits(reported)
+}
+
+// formatFloat shortest representation of value rounded to 4 decimals
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1e4)/1e4, 'f', -1, 64)
+}
@@ -0,0 +1,47 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func newSets(nsPerOps ...float64) []bench.Set {
+	benchmarks := make(bench.BenchmarkList, 0, len(nsPerOps))
+	for _, nsPerOp := range nsPerOps {
+		benchmarks = append(benchmarks, bench.Benchmark{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", NsPerOp: nsPerOp, Mem: bench.Mem{AllocsPerOp: 1}})
+	}
+	return []bench.Set{{Pkg: "demo", Targets: map[string]bench.BenchmarkList{
+		"Fib": benchmarks,
+		"Sum": {{Name: "BenchmarkSum", NsPerOp: 1}},
+	}}}
+}
+
+func TestCompare(t *testing.T) {
+	convey.Convey("Compare Benchmark of two revisions", t, func() {
+		oldSets, newSets := newSets(100, 101, 99, 100), newSets(120, 121, 119, 120)
+		newSets[0].Targets["Sum"] = nil
+		rows := Sets(oldSets, newSets)
+
+		convey.Convey("Every reported metric of Benchmark in both sides is compared", func() {
+			convey.So(rows, convey.ShouldHaveLength, 2)
+			convey.So(rows[0].Name, convey.ShouldEqual, "BenchmarkFib/10")
+			convey.So(rows[0].Unit, convey.ShouldEqual, "ns/op")
+			convey.So(rows[0].Old, convey.ShouldResemble, []float64{100, 101, 99, 100})
+			convey.So(rows[0].Change, convey.ShouldAlmostEqual, 0.2, 1e-12)
+			convey.So(rows[0].Verdict(0.99, 0.02), convey.ShouldEqual, VerdictRegression)
+			convey.So(rows[1].Unit, convey.ShouldEqual, "allocs/op")
+			convey.So(rows[1].Verdict(0.99, 0.02), convey.ShouldEqual, VerdictSame)
+		})
+
+		convey.Convey("Write a text table", func() {
+			builder := new(strings.Builder)
+			convey.So(WriteText(builder, rows, 0.99, 0.02), convey.ShouldBeNil)
+			lines := strings.Split(strings.TrimSpace(builder.String()), "\n")
+			convey.So(lines, convey.ShouldHaveLength, 3)
+			convey.So(strings.Fields(lines[1]), convey.ShouldResemble, []string{"demo", "BenchmarkFib/10", "ns/op", "100", "120", "+20.00%", "0.000", "4+4", "regression"})
+		})
+	})
+}
@@ -0,0 +1,275 @@
+// Package config load benchvisual project config file(.benchvisual.yaml)
+//
+//	@update 2026-10-19 15:55:47
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName name of the project config file, which is discovered from the working directory upward
+const FileName = ".benchvisual.yaml"
+
+// envPattern ${NAME} or ${NAME:-default}, the bare $NAME form is not supported to keep '$' in regexps
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// Config project config file
+//
+//	# values of root command flags, keyed by flag name
+//	sep: /
+//	output: ./benchmark
+//	baseline: [100, 1000, 10]
+//	# rules to split Benchmark name, tried in order before sep / regex, optionally scoped by package
+//	rules:
+//	  - pkg: .*/randfloat64$
+//	    regex: ^Bench(mark)?(?<target>\S+?)/(?<scenario>\S+)$
+//	  - sep: _
+//	# named profiles, selected by --profile, override the values above
+//	profiles:
+//	  ci:
+//	    format: junit
+//	    output: ${CI_PROJECT_DIR:-.}/reports
+//
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+type Config struct {
+	Options
+	Profiles map[string]Options
+
+	Path string // path of the loaded file
+}
+
+// Options flag values and name rules, at top level or in a profile
+type Options struct {
+	Rules []NameRule
+	Flags map[string]yaml.Node // flag name -> flag value
+}
+
+// NameRule separator or regexp to split Benchmark name, for packages matching Pkg(a Go regexp) or all packages when Pkg is empty
+type NameRule struct {
+	Pkg   string `yaml:"pkg,omitempty"`
+	Sep   string `yaml:"sep,omitempty"`
+	Regex string `yaml:"regex,omitempty"`
+}
+
+// UnmarshalYAML implement yaml.Unmarshaler, 'profiles' is taken out before decoding the rest as Options
+//
+//	@receiver config *Config
+//	@param node *yaml.Node
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+func (config *Config) UnmarshalYAML(node *yaml.Node) (err error) {
+	rest, profiles := takeKey(node, "profiles")
+	if profiles != nil {
+		if err = profiles.Decode(&config.Profiles); err != nil {
+			return
+		}
+	}
+	return rest.Decode(&config.Options)
+}
+
+// UnmarshalYAML implement yaml.Unmarshaler, 'rules' is decoded as name rules and other keys as flags
+//
+//	@receiver options *Options
+//	@param node *yaml.Node
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+func (options *Options) UnmarshalYAML(node *yaml.Node) (err error) {
+	rest, rules := takeKey(node, "rules")
+	if rules != nil {
+		if err = rules.Decode(&options.Rules); err != nil {
+			return
+		}
+	}
+	return rest.Decode(&options.Flags)
+}
+
+// takeKey split the value of key out of a mapping node, return the mapping node without key
+func takeKey(node *yaml.Node, key string) (rest *yaml.Node, value *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return node, nil
+	}
+	copied := *node
+	copied.Content = make([]*yaml.Node, 0, len(node.Content))
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			value = node.Content[idx+1]
+			continue
+		}
+		copied.Content = append(copied.Content, node.Content[idx], node.Content[idx+1])
+	}
+	return &copied, value
+}
+
+// Find find the config file from dir upward to the root directory
+//
+//	@param dir string
+//	@return path string empty when not found
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+func Find(dir string) (path string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path = filepath.Join(dir, FileName)
+		if _, err = os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("[Find] error when stat config file %s: %w", path, err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load load config file, environment variables in the form ${NAME} or ${NAME:-default} are interpolated
+// in scalar values after parsing, so that a variable can neither add keys nor change the structure of the file
+//
+//	@param path string
+//	@return config *Config
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 23:10:42
+func Load(path string) (config *Config, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("[Load] error when read config file: %w", err)
+	}
+	var document yaml.Node
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("[Load] error when parse config file %s: %w", path, err)
+	}
+	config = &Config{Path: path}
+	if len(document.Content) == 0 {
+		// empty file
+		return
+	}
+	expandNode(&document)
+	if err = document.Decode(config); err != nil {
+		return nil, fmt.Errorf("[Load] error when parse config file %s: %w", path, err)
+	}
+	return
+}
+
+// expandNode interpolate environment variables in scalar values of a node tree, keys are kept as they are
+func expandNode(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if expanded := ExpandEnv(node.Value); expanded != node.Value {
+			node.Value = expanded
+			if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				// resolve the tag of a plain scalar again by its expanded value
+				node.Tag = ""
+			}
+		}
+	case yaml.MappingNode:
+		for idx := 1; idx < len(node.Content); idx += 2 {
+			expandNode(node.Content[idx])
+		}
+	default:
+		for _, child := range node.Content {
+			expandNode(child)
+		}
+	}
+}
+
+// ExpandEnv replace ${NAME} and ${NAME:-default} with environment variables
+//
+//	@param s string
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+func ExpandEnv(s string) string {
+	return envPattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := envPattern.FindStringSubmatch(match)
+		if value, ok := os.LookupEnv(groups[1]); ok && value != "" {
+			return value
+		}
+		return groups[3]
+	})
+}
+
+// Resolve merge top level options with the given profile, values of the profile take precedence,
+// name rules of the profile are tried before top level ones
+//
+//	@receiver config *Config
+//	@param profile string empty for top level options only
+//	@return options Options
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+func (config *Config) Resolve(profile string) (options Options, err error) {
+	options.Flags = make(map[string]yaml.Node, len(config.Flags))
+	for name, value := range config.Flags {
+		options.Flags[name] = value
+	}
+	options.Rules = config.Rules
+	if profile == "" {
+		return
+	}
+
+	profileOptions, ok := config.Profiles[profile]
+	if !ok {
+		return options, fmt.Errorf("[Resolve] profile %q not found in %s", profile, config.Path)
+	}
+	for name, value := range profileOptions.Flags {
+		options.Flags[name] = value
+	}
+	options.Rules = append(append([]NameRule{}, profileOptions.Rules...), options.Rules...)
+	return
+}
+
+// FlagValue format a flag value in config into the string form accepted by pflag, lists are joined by ','
+//
+//	@param node yaml.Node
+//	@return value string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 15:55:47
+func FlagValue(node yaml.Node) (value string, err error) {
+	values, err := FlagValues(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(values, ","), nil
+}
+
+// FlagValues values of a flag in config, a scalar is taken as a list of one item
+//
+//	@param node yaml.Node
+//	@return values []string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 16:31:05
+func FlagValues(node yaml.Node) (values []string, err error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		values = make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("[FlagValues] line %d: only list of scalars is supported", item.Line)
+			}
+			values = append(values, item.Value)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("[FlagValues] line %d: flag value should be a scalar or a list of scalars", node.Line)
+	}
+}
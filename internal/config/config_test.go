@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const testConfig = `sep: /
+output: ${BENCHVISUAL_TEST_OUTPUT:-./benchmark}
+baseline: [100, 1000, 10]
+regex: '^Bench(mark)?(?<target>\S+)/(?<scenario>\S+)$'
+rules:
+  - pkg: .*/randfloat64$
+    regex: ^Bench(mark)?(?<target>\S+?)/(?<scenario>\S+)$
+profiles:
+  ci:
+    format: junit
+    output: ${BENCHVISUAL_TEST_CI_DIR}/reports
+    rules:
+      - pkg: .*/sleep$
+        sep: "-"
+`
+
+func TestLoad(t *testing.T) {
+	convey.Convey("Given a config file in a parent directory", t, func() {
+		root := t.TempDir()
+		nested := filepath.Join(root, "a", "b")
+		convey.So(os.MkdirAll(nested, 0o755), convey.ShouldBeNil)
+		convey.So(os.WriteFile(filepath.Join(root, FileName), []byte(testConfig), 0o644), convey.ShouldBeNil)
+		t.Setenv("BENCHVISUAL_TEST_CI_DIR", "/ci")
+
+		convey.Convey("Find it from a nested directory and load it", func() {
+			path, err := Find(nested)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(path, convey.ShouldEqual, filepath.Join(root, FileName))
+
+			config, err := Load(path)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(config.Profiles, convey.ShouldContainKey, "ci")
+			convey.So(config.Flags, convey.ShouldNotContainKey, "profiles")
+			convey.So(config.Flags, convey.ShouldNotContainKey, "rules")
+
+			convey.Convey("Resolve top level options", func() {
+				options, err := config.Resolve("")
+				convey.So(err, convey.ShouldBeNil)
+				output, _ := FlagValue(options.Flags["output"])
+				convey.So(output, convey.ShouldEqual, "./benchmark")
+				baseline, _ := FlagValue(options.Flags["baseline"])
+				convey.So(baseline, convey.ShouldEqual, "100,1000,10")
+				regex, _ := FlagValue(options.Flags["regex"])
+				convey.So(regex, convey.ShouldEqual, `^Bench(mark)?(?<target>\S+)/(?<scenario>\S+)$`)
+				convey.So(options.Rules, convey.ShouldResemble, []NameRule{{Pkg: ".*/randfloat64$", Regex: `^Bench(mark)?(?<target>\S+?)/(?<scenario>\S+)$`}})
+			})
+			convey.Convey("Resolve a profile", func() {
+				options, err := config.Resolve("ci")
+				convey.So(err, convey.ShouldBeNil)
+				output, _ := FlagValue(options.Flags["output"])
+				convey.So(output, convey.ShouldEqual, "/ci/reports")
+				format, _ := FlagValue(options.Flags["format"])
+				convey.So(format, convey.ShouldEqual, "junit")
+				convey.So(options.Rules, convey.ShouldHaveLength, 2)
+				convey.So(options.Rules[0].Pkg, convey.ShouldEqual, ".*/sleep$")
+			})
+			convey.Convey("Resolve an unknown profile", func() {
+				_, err := config.Resolve("unknown")
+				convey.So(err, convey.ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestLoadEnvInjection(t *testing.T) {
+	convey.Convey("Given a config file with an environment variable whose value looks like YAML", t, func() {
+		path := filepath.Join(t.TempDir(), FileName)
+		convey.So(os.WriteFile(path, []byte("output: ${BENCHVISUAL_TEST_OUTPUT}\nsep: /\n"), 0o644), convey.ShouldBeNil)
+		t.Setenv("BENCHVISUAL_TEST_OUTPUT", "./x\nformat: junit")
+
+		convey.Convey("The value is interpolated as a whole without adding keys", func() {
+			config, err := Load(path)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(config.Flags, convey.ShouldNotContainKey, "format")
+			output, _ := FlagValue(config.Flags["output"])
+			convey.So(output, convey.ShouldEqual, "./x\nformat: junit")
+			sep, _ := FlagValue(config.Flags["sep"])
+			convey.So(sep, convey.ShouldEqual, "/")
+		})
+	})
+}
+
+func TestExpandEnv(t *testing.T) {
+	convey.Convey("Given strings with environment variables", t, func() {
+		t.Setenv("BENCHVISUAL_TEST_ENV", "value")
+		convey.So(ExpandEnv("${BENCHVISUAL_TEST_ENV}/x"), convey.ShouldEqual, "value/x")
+		convey.So(ExpandEnv("${BENCHVISUAL_TEST_UNSET:-fallback}"), convey.ShouldEqual, "fallback")
+		convey.So(ExpandEnv("${BENCHVISUAL_TEST_UNSET}"), convey.ShouldEqual, "")
+		// bare '$' is kept for regexps
+		convey.So(ExpandEnv("^Bench$ $HOME"), convey.ShouldEqual, "^Bench$ $HOME")
+	})
+}
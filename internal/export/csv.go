@@ -0,0 +1,113 @@
+// Package export export parsed benchmark sets to formats other than html and json
+//
+//	@update 2026-10-19 10:12:31
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/collections"
+)
+
+// csvHeader fixed columns of the flat export, custom metric units are appended after them
+var csvHeader = []string{"pkg", "goos", "goarch", "cpu", "name", "target", "scenario", "cores", "runs", "ns/op", "B/op", "allocs/op", "MB/s"}
+
+// CSV write benchmark sets as a flat table, one row per benchmark (every sample of a '-count' run is a row)
+// there is one column per custom metric unit found in the whole input, the cell is left empty
+// when the benchmark did not report that unit, and so are B/op, allocs/op and MB/s
+//
+//	@param w io.Writer
+//	@param sets []bench.Set
+//	@param comma rune field delimiter, ',' for CSV and '\t' for TSV
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 01:31:05
+func CSV(w io.Writer, sets []bench.Set, comma rune) (err error) {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	units := CustomUnits(sets)
+	if err = writer.Write(append(append([]string{}, csvHeader...), units...)); err != nil {
+		return
+	}
+
+	for _, set := range sets {
+		for _, target := range sortedTargets(set) {
+			for _, benchmark := range set.Targets[target] {
+				record := []string{
+					set.Pkg, set.Goos, set.Goarch, set.CPU,
+					benchmark.Name, benchmark.Target, benchmark.Scenario,
+					strconv.Itoa(benchmark.CPUCores), strconv.Itoa(benchmark.Runs),
+					formatFloat(benchmark.NsPerOp), formatReported(&benchmark, "B/op", benchmark.Mem.BytesPerOp),
+					formatReported(&benchmark, "allocs/op", benchmark.Mem.AllocsPerOp), formatReported(&benchmark, "MB/s", benchmark.Mem.MBPerSec),
+				}
+				for _, unit := range units {
+					if value, ok := benchmark.CustomMetrics[unit]; ok {
+						record = append(record, formatFloat(value))
+					} else {
+						record = append(record, "")
+					}
+				}
+				if err = writer.Write(record); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// CustomUnits get all unique custom metric units in benchmark sets, sorted
+//
+//	@param sets []bench.Set
+//	@return units []string
+//	@author kevineluo
+//	@update 2026-10-19 10:12:31
+func CustomUnits(sets []bench.Set) (units []string) {
+	unitSet := collections.NewSet[string](0)
+	for _, set := range sets {
+		for _, benchmarks := range set.Targets {
+			for _, benchmark := range benchmarks {
+				for unit := range benchmark.CustomMetrics {
+					unitSet.Add(unit)
+				}
+			}
+		}
+	}
+	units = unitSet.ToSlice()
+	sort.Strings(units)
+	return
+}
+
+// sortedTargets targets of a set in a stable order
+func sortedTargets(set bench.Set) []string {
+	targets := collections.Keys(set.Targets)
+	sort.Strings(targets)
+	return targets
+}
+
+// sortedUnits custom metric units of a benchmark in a stable order
+func sortedUnits(customMetrics map[string]float64) []string {
+	units := collections.Keys(customMetrics)
+	sort.Strings(units)
+	return units
+}
+
+// formatFloat format metric value in the shortest representation
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatReported format metric value of the unit, empty when the benchmark does not report it
+func formatReported(benchmark *bench.Benchmark, unit string, value float64) string {
+	if !benchmark.Reports(unit) {
+		return ""
+	}
+	return formatFloat(value)
+}
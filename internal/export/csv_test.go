@@ -0,0 +1,46 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+var testSets = []bench.Set{
+	{
+		Goos:   "linux",
+		Goarch: "amd64",
+		Pkg:    "github.com/Kevinello/demo",
+		CPU:    "AMD EPYC 7K62 48-Core Processor",
+		Targets: map[string]bench.BenchmarkList{
+			"Pizzas": {
+				{Name: "BenchmarkPizzas/10", CPUCores: 16, Runs: 22866814, NsPerOp: 46.3, CustomMetrics: map[string]float64{"pizzas": 9}, Target: "Pizzas", Scenario: "10"},
+			},
+			"Fib": {
+				{Name: "BenchmarkFib/10", CPUCores: 16, Runs: 3033732, NsPerOp: 358, Mem: bench.Mem{BytesPerOp: 16, AllocsPerOp: 1}, Target: "Fib", Scenario: "10"},
+			},
+		},
+	},
+}
+
+func TestCSV(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets", t, func() {
+		convey.Convey("Export them as CSV", func() {
+			builder := new(strings.Builder)
+			err := CSV(builder, testSets, ',')
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(builder.String(), convey.ShouldEqual, `pkg,goos,goarch,cpu,name,target,scenario,cores,runs,ns/op,B/op,allocs/op,MB/s,pizzas
+github.com/Kevinello/demo,linux,amd64,AMD EPYC 7K62 48-Core Processor,BenchmarkFib/10,Fib,10,16,3033732,358,16,1,,
+github.com/Kevinello/demo,linux,amd64,AMD EPYC 7K62 48-Core Processor,BenchmarkPizzas/10,Pizzas,10,16,22866814,46.3,,,,9
+`)
+		})
+		convey.Convey("Export them as TSV", func() {
+			builder := new(strings.Builder)
+			err := CSV(builder, testSets, '\t')
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(strings.SplitN(builder.String(), "\n", 2)[0], convey.ShouldEqual, "pkg\tgoos\tgoarch\tcpu\tname\ttarget\tscenario\tcores\truns\tns/op\tB/op\tallocs/op\tMB/s\tpizzas")
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+)
+
+// DefaultMeasurement default InfluxDB measurement of benchmark points
+const DefaultMeasurement = "go_benchmark"
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+	// builtinFields field keys of builtin metrics, which custom metrics must not take
+	builtinFields = map[string]struct{}{"runs": {}, "ns_per_op": {}, "bytes_per_op": {}, "allocs_per_op": {}, "mb_per_sec": {}}
+)
+
+// Influx write benchmark sets in InfluxDB line protocol, one point per benchmark
+//
+// tags are pkg, target, scenario, goos, goarch, cpu and procs(empty tags are omitted as InfluxDB required),
+// fields are every metric reported include custom metrics, timestamps are in nanosecond precision.
+// a custom metric whose unit is a builtin field key(e.g. 'runs') is prefixed by 'custom_', e.g. 'custom_runs'
+//
+//	@param w io.Writer
+//	@param sets []bench.Set
+//	@param measurement string
+//	@param timestamp time.Time configured timestamp of all points, when zero the run date of set(or now) is used
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 01:38:22
+func Influx(w io.Writer, sets []bench.Set, measurement string, timestamp time.Time) (err error) {
+	builder := new(strings.Builder)
+	for _, set := range sets {
+		setTimestamp := timestamp
+		if setTimestamp.IsZero() {
+			if setTimestamp, err = time.Parse(time.RFC3339, set.Date); err != nil {
+				setTimestamp, err = time.Now(), nil
+			}
+		}
+		for _, target := range sortedTargets(set) {
+			// samples of the same benchmark need a 'sample' tag, or they will overwrite each other
+			sampleTotal, sampleIdx := make(map[string]int), make(map[string]int)
+			for _, benchmark := range set.Targets[target] {
+				sampleTotal[sampleKey(&benchmark)]++
+			}
+			for _, benchmark := range set.Targets[target] {
+				tags := [][2]string{
+					{"pkg", set.Pkg}, {"target", benchmark.Target}, {"scenario", benchmark.Scenario},
+					{"goos", set.Goos}, {"goarch", set.Goarch}, {"cpu", set.CPU}, {"procs", strconv.Itoa(benchmark.CPUCores)},
+				}
+				if key := sampleKey(&benchmark); sampleTotal[key] > 1 {
+					tags = append(tags, [2]string{"sample", strconv.Itoa(sampleIdx[key])})
+					sampleIdx[key]++
+				}
+
+				builder.WriteString(measurementEscaper.Replace(measurement))
+				for _, tag := range tags {
+					if tag[1] != "" {
+						fmt.Fprintf(builder, ",%s=%s", keyEscaper.Replace(tag[0]), keyEscaper.Replace(tag[1]))
+					}
+				}
+
+				fields := []string{
+					"runs=" + strconv.Itoa(benchmark.Runs) + "i",
+					"ns_per_op=" + formatFloat(benchmark.NsPerOp),
+				}
+				// metrics not reported(without -benchmem or b.SetBytes) are left out rather than written as 0
+				if benchmark.Reports("B/op") {
+					fields = append(fields, "bytes_per_op="+formatFloat(benchmark.Mem.BytesPerOp), "allocs_per_op="+formatFloat(benchmark.Mem.AllocsPerOp))
+				}
+				if benchmark.Reports("MB/s") {
+					fields = append(fields, "mb_per_sec="+formatFloat(benchmark.Mem.MBPerSec))
+				}
+				for _, unit := range sortedUnits(benchmark.CustomMetrics) {
+					fields = append(fields, keyEscaper.Replace(customFieldKey(unit, benchmark.CustomMetrics))+"="+formatFloat(benchmark.CustomMetrics[unit]))
+				}
+				fmt.Fprintf(builder, " %s %d\n", strings.Join(fields, ","), setTimestamp.UnixNano())
+			}
+		}
+	}
+	_, err = io.WriteString(w, builder.String())
+	return
+}
+
+// customFieldKey field key of a custom metric, prefixed by 'custom_' until it collides with neither
+// a builtin field nor another custom metric of the point
+func customFieldKey(unit string, customMetrics map[string]float64) string {
+	key := unit
+	for {
+		if _, builtin := builtinFields[key]; !builtin {
+			if _, custom := customMetrics[key]; !custom || key == unit {
+				return key
+			}
+		}
+		key = "custom_" + key
+	}
+}
+
+// PushInflux POST a batch of line protocol to an InfluxDB v2 write endpoint
+//
+//	@param ctx context.Context
+//	@param url string full write endpoint, e.g. http://localhost:8086/api/v2/write?org=my-org&bucket=bench&precision=ns
+//	@param token string API token, skip authorization when empty
+//	@param batch []byte line protocol
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 12:03:38
+func PushInflux(ctx context.Context, url string, token string, batch []byte) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(batch))
+	if err != nil {
+		return fmt.Errorf("[PushInflux] error when build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("[PushInflux] error when push to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("[PushInflux] unexpected status %s from %s: %s", resp.Status, url, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestInflux(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets", t, func() {
+		timestamp := time.Unix(1700000000, 0)
+		convey.Convey("Export them as InfluxDB line protocol", func() {
+			builder := new(strings.Builder)
+			err := Influx(builder, testSets, DefaultMeasurement, timestamp)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(builder.String(), convey.ShouldEqual, `go_benchmark,pkg=github.com/Kevinello/demo,target=Fib,scenario=10,goos=linux,goarch=amd64,cpu=AMD\ EPYC\ 7K62\ 48-Core\ Processor,procs=16 runs=3033732i,ns_per_op=358,bytes_per_op=16,allocs_per_op=1 1700000000000000000
+go_benchmark,pkg=github.com/Kevinello/demo,target=Pizzas,scenario=10,goos=linux,goarch=amd64,cpu=AMD\ EPYC\ 7K62\ 48-Core\ Processor,procs=16 runs=22866814i,ns_per_op=46.3,pizzas=9 1700000000000000000
+`)
+		})
+	})
+}
+
+func TestInfluxFieldCollision(t *testing.T) {
+	convey.Convey("Given custom metrics named as builtin fields", t, func() {
+		sets := []bench.Set{{
+			Pkg: "demo",
+			Targets: map[string]bench.BenchmarkList{
+				"Cache": {
+					{Name: "BenchmarkCache/hit", CPUCores: 8, Runs: 100, NsPerOp: 12, CustomMetrics: map[string]float64{"runs": 3, "custom_runs": 4, "hits": 5}, Target: "Cache", Scenario: "hit"},
+				},
+			},
+		}}
+		convey.Convey("Custom fields are prefixed instead of overwriting builtin fields", func() {
+			builder := new(strings.Builder)
+			err := Influx(builder, sets, DefaultMeasurement, time.Unix(1, 0))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(builder.String(), convey.ShouldEqual, "go_benchmark,pkg=demo,target=Cache,scenario=hit,procs=8 runs=100i,ns_per_op=12,custom_runs=4,hits=5,custom_custom_runs=3 1000000000\n")
+		})
+	})
+}
+
+func TestPushInflux(t *testing.T) {
+	convey.Convey("Given a stand-in InfluxDB v2 write endpoint", t, func() {
+		var (
+			gotAuth  string
+			gotQuery string
+			gotBody  string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v2/write" {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			gotAuth, gotQuery, gotBody = r.Header.Get("Authorization"), r.URL.RawQuery, string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		convey.Convey("Push a batch to it", func() {
+			err := PushInflux(context.Background(), server.URL+"/api/v2/write?org=demo&bucket=bench", "secret", []byte("go_benchmark ns_per_op=1 1\n"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(gotAuth, convey.ShouldEqual, "Token secret")
+			convey.So(gotQuery, convey.ShouldEqual, "org=demo&bucket=bench")
+			convey.So(gotBody, convey.ShouldEqual, "go_benchmark ns_per_op=1 1\n")
+		})
+		convey.Convey("Push to a wrong endpoint should fail", func() {
+			err := PushInflux(context.Background(), server.URL+"/write", "", []byte("go_benchmark ns_per_op=1 1\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "404")
+		})
+	})
+}
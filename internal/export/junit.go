@@ -0,0 +1,148 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/collections"
+)
+
+// JUnitTestSuites root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite a package of benchmark
+type JUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	TestCases  []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase a single benchmark
+type JUnitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	Failure    *JUnitFailure   `xml:"failure,omitempty"`
+}
+
+// JUnitProperty key value pair attached to suite or case
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitFailure baseline check failure of a benchmark
+type JUnitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// JUnit write benchmark sets as JUnit XML report, every set is a testsuite and every benchmark is a testcase,
+// a benchmark which does not reach its baseline(see bench.Baseline) is reported as a failure
+//
+//	@param w io.Writer
+//	@param sets []bench.Set
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 11:14:09
+func JUnit(w io.Writer, sets []bench.Set) (err error) {
+	report := JUnitTestSuites{Name: "benchvisual"}
+	for _, set := range sets {
+		suite := JUnitTestSuite{
+			Name: set.Pkg,
+			Properties: collections.Filter([]JUnitProperty{
+				{Name: "goos", Value: set.Goos},
+				{Name: "goarch", Value: set.Goarch},
+				{Name: "cpu", Value: set.CPU},
+			}, func(property JUnitProperty) bool { return property.Value != "" }),
+		}
+		var suiteSeconds float64
+		for _, target := range sortedTargets(set) {
+			for _, benchmark := range set.Targets[target] {
+				// total time spent in the measured loop
+				seconds := benchmark.NsPerOp * float64(benchmark.Runs) / 1e9
+				suiteSeconds += seconds
+				testCase := JUnitTestCase{
+					Name:       benchmark.Name,
+					Classname:  set.Pkg,
+					Time:       formatSeconds(seconds),
+					Properties: junitMetricProperties(&benchmark),
+				}
+				if len(benchmark.BaselineMisses) > 0 {
+					testCase.Failure = junitFailure(benchmark.BaselineMisses)
+					suite.Failures++
+				}
+				suite.TestCases = append(suite.TestCases, testCase)
+			}
+		}
+		suite.Tests = len(suite.TestCases)
+		suite.Time = formatSeconds(suiteSeconds)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "    ")
+	if err = encoder.Encode(report); err != nil {
+		return
+	}
+	_, err = io.WriteString(w, "\n")
+	return
+}
+
+// junitMetricProperties measured metrics of a benchmark as testcase properties, metrics not reported are absent
+func junitMetricProperties(benchmark *bench.Benchmark) (properties []JUnitProperty) {
+	properties = []JUnitProperty{
+		{Name: "target", Value: benchmark.Target},
+		{Name: "scenario", Value: benchmark.Scenario},
+		{Name: "cores", Value: fmt.Sprint(benchmark.CPUCores)},
+		{Name: "runs", Value: fmt.Sprint(benchmark.Runs)},
+		{Name: "ns/op", Value: formatFloat(benchmark.NsPerOp)},
+	}
+	for _, metric := range []struct {
+		unit  string
+		value float64
+	}{{"B/op", benchmark.Mem.BytesPerOp}, {"allocs/op", benchmark.Mem.AllocsPerOp}, {"MB/s", benchmark.Mem.MBPerSec}} {
+		if benchmark.Reports(metric.unit) {
+			properties = append(properties, JUnitProperty{Name: metric.unit, Value: formatFloat(metric.value)})
+		}
+	}
+	for _, unit := range sortedUnits(benchmark.CustomMetrics) {
+		properties = append(properties, JUnitProperty{Name: unit, Value: formatFloat(benchmark.CustomMetrics[unit])})
+	}
+	return
+}
+
+// junitFailure build failure element from baseline misses
+func junitFailure(misses []bench.BaselineMiss) *JUnitFailure {
+	descriptions := collections.Map(misses, func(miss bench.BaselineMiss) string { return miss.String() })
+	return &JUnitFailure{
+		Message:  "baseline not reached: " + strings.Join(descriptions, "; "),
+		Type:     "baseline",
+		Contents: strings.Join(descriptions, "\n"),
+	}
+}
+
+// formatSeconds format duration in seconds with millisecond precision like go test does
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
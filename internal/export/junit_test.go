@@ -0,0 +1,46 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestJUnit(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets checked with baselines", t, func() {
+		sets := []bench.Set{{
+			Pkg:     testSets[0].Pkg,
+			Goos:    testSets[0].Goos,
+			Targets: map[string]bench.BenchmarkList{"Fib": append(bench.BenchmarkList{}, testSets[0].Targets["Fib"]...)},
+		}}
+		bench.Baseline(sets, []float64{1000, 0, 0})
+		convey.Convey("Export them as JUnit XML", func() {
+			builder := new(strings.Builder)
+			err := JUnit(builder, sets)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(builder.String(), convey.ShouldEqual, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="benchvisual" tests="1" failures="1">
+    <testsuite name="github.com/Kevinello/demo" tests="1" failures="1" time="1.086">
+        <properties>
+            <property name="goos" value="linux"></property>
+        </properties>
+        <testcase name="BenchmarkFib/10" classname="github.com/Kevinello/demo" time="1.086">
+            <properties>
+                <property name="target" value="Fib"></property>
+                <property name="scenario" value="10"></property>
+                <property name="cores" value="16"></property>
+                <property name="runs" value="3033732"></property>
+                <property name="ns/op" value="358"></property>
+                <property name="B/op" value="16"></property>
+                <property name="allocs/op" value="1"></property>
+            </properties>
+            <failure message="baseline not reached: ns/op: actual 5728, should be less than baseline 1000" type="baseline">ns/op: actual 5728, should be less than baseline 1000</failure>
+        </testcase>
+    </testsuite>
+</testsuites>
+`)
+		})
+	})
+}
@@ -0,0 +1,172 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+)
+
+// metricPrefix prefix of all exported OpenMetrics metric names
+const metricPrefix = "go_benchmark_"
+
+var invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_:]+`)
+
+// builtinFamilies names of metric families exported for builtin metrics, which custom metrics must not take
+var builtinFamilies = []string{"runs", "ns_per_op", "bytes_per_op", "allocs_per_op", "mb_per_sec", "reach_baseline"}
+
+// metricFamily a gauge family with all of its samples
+type metricFamily struct {
+	name    string
+	help    string
+	samples []string
+}
+
+// OpenMetrics write benchmark sets as OpenMetrics gauges, which can be collected by node_exporter textfile collector
+//
+// every metric of a benchmark(include custom metrics) is a gauge labeled with pkg, target, scenario, cpu, goarch and procs,
+// samples of a benchmark run with '-count' are distinguished by a 'sample' label, metrics not reported have no sample.
+// a custom metric whose name collides with a builtin metric or another custom metric(e.g. 'runs', or 'a/op' and 'a_per_op')
+// is suffixed by a number, e.g. 'go_benchmark_runs_2', the original unit is kept in its HELP
+//
+//	@param w io.Writer
+//	@param sets []bench.Set
+//	@param withBaseline bool export baseline status as a 0/1 gauge, only make sense after bench.Baseline
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 01:38:22
+func OpenMetrics(w io.Writer, sets []bench.Set, withBaseline bool) (err error) {
+	families := make([]*metricFamily, 0)
+	familyIndex := make(map[string]*metricFamily)
+	add := func(name, help, labels string, value float64) {
+		family, ok := familyIndex[name]
+		if !ok {
+			family = &metricFamily{name: name, help: help}
+			familyIndex[name] = family
+			families = append(families, family)
+		}
+		family.samples = append(family.samples, fmt.Sprintf("%s{%s} %s", name, labels, strconv.FormatFloat(value, 'g', -1, 64)))
+	}
+
+	customNames := customMetricNames(sets)
+	for _, set := range sets {
+		for _, target := range sortedTargets(set) {
+			// samples of the same benchmark need a 'sample' label to keep every series unique
+			sampleTotal, sampleIdx := make(map[string]int), make(map[string]int)
+			for _, benchmark := range set.Targets[target] {
+				sampleTotal[sampleKey(&benchmark)]++
+			}
+			for _, benchmark := range set.Targets[target] {
+				labelPairs := [][2]string{
+					{"pkg", set.Pkg}, {"target", benchmark.Target}, {"scenario", benchmark.Scenario},
+					{"cpu", set.CPU}, {"goarch", set.Goarch}, {"procs", strconv.Itoa(benchmark.CPUCores)},
+				}
+				if key := sampleKey(&benchmark); sampleTotal[key] > 1 {
+					labelPairs = append(labelPairs, [2]string{"sample", strconv.Itoa(sampleIdx[key])})
+					sampleIdx[key]++
+				}
+				labels := formatLabels(labelPairs)
+
+				add(metricPrefix+"runs", "Iterations of the benchmark loop.", labels, float64(benchmark.Runs))
+				add(metricPrefix+"ns_per_op", "Time cost per operation in nanoseconds.", labels, benchmark.NsPerOp)
+				// metrics not reported(without -benchmem or b.SetBytes) have no sample rather than a fake 0
+				if benchmark.Reports("B/op") {
+					add(metricPrefix+"bytes_per_op", "Allocated bytes per operation.", labels, benchmark.Mem.BytesPerOp)
+				}
+				if benchmark.Reports("allocs/op") {
+					add(metricPrefix+"allocs_per_op", "Allocations per operation.", labels, benchmark.Mem.AllocsPerOp)
+				}
+				if benchmark.Reports("MB/s") {
+					add(metricPrefix+"mb_per_sec", "Throughput in MB per second.", labels, benchmark.Mem.MBPerSec)
+				}
+				for _, unit := range sortedUnits(benchmark.CustomMetrics) {
+					add(customNames[unit], fmt.Sprintf("Custom metric %q.", unit), labels, benchmark.CustomMetrics[unit])
+				}
+				if withBaseline {
+					reach := 0.0
+					if benchmark.ReachBaseline {
+						reach = 1
+					}
+					add(metricPrefix+"reach_baseline", "Whether the benchmark reach its baseline(1) or not(0).", labels, reach)
+				}
+			}
+		}
+	}
+
+	builder := new(strings.Builder)
+	for _, family := range families {
+		fmt.Fprintf(builder, "# TYPE %s gauge\n# HELP %s %s\n", family.name, family.name, family.help)
+		for _, sample := range family.samples {
+			builder.WriteString(sample)
+			builder.WriteByte('\n')
+		}
+	}
+	builder.WriteString("# EOF\n")
+	_, err = io.WriteString(w, builder.String())
+	return
+}
+
+// MetricName sanitise a benchmark unit into a valid OpenMetrics metric name, e.g. 'p99-ns/op' -> 'go_benchmark_p99_ns_per_op'
+//
+//	@param unit string
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 11:40:52
+func MetricName(unit string) string {
+	name := strings.ReplaceAll(unit, "/", "_per_")
+	name = invalidMetricChars.ReplaceAllString(name, "_")
+	return metricPrefix + strings.ToLower(strings.Trim(name, "_"))
+}
+
+// customMetricNames metric names of custom metric units in sets, units are named in sorted order
+// and a name already taken by a builtin family or a former unit is suffixed by a number
+func customMetricNames(sets []bench.Set) map[string]string {
+	names := make(map[string]string)
+	var units []string
+	for _, set := range sets {
+		for _, benchmarks := range set.Targets {
+			for _, benchmark := range benchmarks {
+				for unit := range benchmark.CustomMetrics {
+					if _, ok := names[unit]; !ok {
+						names[unit] = ""
+						units = append(units, unit)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(units)
+
+	taken := make(map[string]bool)
+	for _, family := range builtinFamilies {
+		taken[metricPrefix+family] = true
+	}
+	for _, unit := range units {
+		name := MetricName(unit)
+		for suffix := 2; taken[name]; suffix++ {
+			name = fmt.Sprintf("%s_%d", MetricName(unit), suffix)
+		}
+		taken[name] = true
+		names[unit] = name
+	}
+	return names
+}
+
+// sampleKey identify samples of the same benchmark
+func sampleKey(benchmark *bench.Benchmark) string {
+	return fmt.Sprintf("%s-%d", benchmark.Name, benchmark.CPUCores)
+}
+
+// formatLabels format label pairs, escaping label values as the exposition format required
+func formatLabels(pairs [][2]string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	labels := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, pair[0], escaper.Replace(pair[1])))
+	}
+	return strings.Join(labels, ",")
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestMetricName(t *testing.T) {
+	convey.Convey("Given custom metric units", t, func() {
+		convey.So(MetricName("pizzas"), convey.ShouldEqual, "go_benchmark_pizzas")
+		convey.So(MetricName("p99-ns/op"), convey.ShouldEqual, "go_benchmark_p99_ns_per_op")
+		convey.So(MetricName("Hit Rate%"), convey.ShouldEqual, "go_benchmark_hit_rate")
+	})
+}
+
+func TestOpenMetrics(t *testing.T) {
+	convey.Convey("Given parsed Benchmark sets with repeated samples", t, func() {
+		sets := []bench.Set{{
+			Pkg:    "demo",
+			CPU:    `Intel "Xeon"`,
+			Goarch: "amd64",
+			Targets: map[string]bench.BenchmarkList{
+				"Pizzas": {
+					{Name: "BenchmarkPizzas/10", CPUCores: 8, Runs: 100, NsPerOp: 46.3, CustomMetrics: map[string]float64{"pizzas": 9}, Target: "Pizzas", Scenario: "10", ReachBaseline: true},
+					{Name: "BenchmarkPizzas/10", CPUCores: 8, Runs: 100, NsPerOp: 47, CustomMetrics: map[string]float64{"pizzas": 9}, Target: "Pizzas", Scenario: "10"},
+				},
+			},
+		}}
+		convey.Convey("Export them as OpenMetrics gauges", func() {
+			builder := new(strings.Builder)
+			err := OpenMetrics(builder, sets, true)
+			convey.So(err, convey.ShouldBeNil)
+			output := builder.String()
+			convey.So(output, convey.ShouldStartWith, "# TYPE go_benchmark_runs gauge\n# HELP go_benchmark_runs Iterations of the benchmark loop.\n")
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_ns_per_op{pkg="demo",target="Pizzas",scenario="10",cpu="Intel \"Xeon\"",goarch="amd64",procs="8",sample="0"} 46.3`)
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_ns_per_op{pkg="demo",target="Pizzas",scenario="10",cpu="Intel \"Xeon\"",goarch="amd64",procs="8",sample="1"} 47`)
+			convey.So(output, convey.ShouldContainSubstring, "# TYPE go_benchmark_pizzas gauge\n")
+			// without -benchmem or b.SetBytes
+			convey.So(output, convey.ShouldNotContainSubstring, "go_benchmark_bytes_per_op")
+			convey.So(output, convey.ShouldNotContainSubstring, "go_benchmark_allocs_per_op")
+			convey.So(output, convey.ShouldNotContainSubstring, "go_benchmark_mb_per_sec")
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_reach_baseline{pkg="demo",target="Pizzas",scenario="10",cpu="Intel \"Xeon\"",goarch="amd64",procs="8",sample="1"} 0`)
+			convey.So(output, convey.ShouldEndWith, "# EOF\n")
+		})
+	})
+}
+
+func TestOpenMetricsNameCollision(t *testing.T) {
+	convey.Convey("Given custom metrics colliding with a builtin metric and with each other", t, func() {
+		sets := []bench.Set{{
+			Pkg: "demo",
+			Targets: map[string]bench.BenchmarkList{
+				"Cache": {
+					{Name: "BenchmarkCache/hit", CPUCores: 8, Runs: 100, NsPerOp: 12, CustomMetrics: map[string]float64{"runs": 3, "a/op": 1, "a_per_op": 2}, Target: "Cache", Scenario: "hit"},
+				},
+			},
+		}}
+		convey.Convey("Every custom metric gets a family of its own", func() {
+			builder := new(strings.Builder)
+			err := OpenMetrics(builder, sets, false)
+			convey.So(err, convey.ShouldBeNil)
+			output := builder.String()
+			convey.So(strings.Count(output, "# TYPE go_benchmark_runs gauge\n"), convey.ShouldEqual, 1)
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_runs{pkg="demo",target="Cache",scenario="hit",cpu="",goarch="",procs="8"} 100`)
+			convey.So(output, convey.ShouldContainSubstring, "# HELP go_benchmark_runs_2 Custom metric \"runs\".\n")
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_runs_2{pkg="demo",target="Cache",scenario="hit",cpu="",goarch="",procs="8"} 3`)
+			convey.So(output, convey.ShouldContainSubstring, "# HELP go_benchmark_a_per_op Custom metric \"a/op\".\n")
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_a_per_op{pkg="demo",target="Cache",scenario="hit",cpu="",goarch="",procs="8"} 1`)
+			convey.So(output, convey.ShouldContainSubstring, "# HELP go_benchmark_a_per_op_2 Custom metric \"a_per_op\".\n")
+			convey.So(output, convey.ShouldContainSubstring, `go_benchmark_a_per_op_2{pkg="demo",target="Cache",scenario="hit",cpu="",goarch="",procs="8"} 2`)
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/stats"
+)
+
+// ChangePointOptions options of change point detection
+type ChangePointOptions struct {
+	Confidence float64 // minimal confidence of a change, e.g. 0.99
+	MinRuns    int     // minimal runs on each side of a change, so that a single noisy run is never a change
+	MinChange  float64 // minimal relative change of the mean, e.g. 0.02 for 2%, smaller shifts are ignored even if significant
+	Window     int     // maximal runs on each side of a split compared by the t-test, so that a shift which is reverted later is found, <= 0 for no limit
+}
+
+// DefaultChangePointOptions default options of change point detection
+var DefaultChangePointOptions = ChangePointOptions{Confidence: 0.99, MinRuns: 3, MinChange: 0.02, Window: 8}
+
+// ChangePoint a statistically significant shift of a metric of a Benchmark over runs
+type ChangePoint struct {
+	Key        Key
+	Unit       string
+	Index      int // index of the first point after the shift in the trend
+	Timestamp  time.Time
+	Commit     string  // first bad(or good) commit, the commit of the first run after the shift
+	Before     float64 // mean of the runs before the shift, until the previous change
+	After      float64 // mean of the runs after the shift, until the next change
+	Change     float64 // relative change of the mean, (After-Before)/Before
+	Confidence float64 // 1 - p-value of Welch's t-test, corrected by the number of tried splits
+	Regression bool    // whether the metric gets worse, higher is better only for throughput units(e.g. MB/s)
+}
+
+// String describe the change in a human readable way
+//
+//	@receiver change ChangePoint
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func (change ChangePoint) String() string {
+	commit := change.Commit
+	if commit == "" {
+		commit = "unknown commit"
+	}
+	return fmt.Sprintf("%s %s: %+.2f%% (%.6g -> %.6g) since %s at %s, confidence %.2f%%", change.Key, change.Unit,
+		change.Change*100, change.Before, change.After, commit, change.Timestamp.Local().Format(time.RFC3339), change.Confidence*100)
+}
+
+// ChangePoints detect change points of a metric in the trend by binary segmentation:
+// the split of runs with the most significant Welch's t-test between both sides(at most Window runs each) is a change
+// when it reaches the confidence, then both sides are segmented again, every segment has at least MinRuns runs.
+// only runs reporting the metric are segmented, so a metric missing in some runs(e.g. added later) is never a change
+//
+//	@receiver trend *Trend
+//	@param unit string
+//	@param options ChangePointOptions
+//	@return changes []ChangePoint sorted by index
+//	@author kevineluo
+//	@update 2026-10-19 23:58:40
+func (trend *Trend) ChangePoints(unit string, options ChangePointOptions) (changes []ChangePoint) {
+	if options.MinRuns < 2 {
+		// Welch's t-test needs at least 2 samples on each side
+		options.MinRuns = 2
+	}
+	// values of runs reporting the metric, with their indexes in points
+	var values []float64
+	var pointIdx []int
+	for idx, value := range trend.Values(unit) {
+		if !math.IsNaN(value) {
+			values = append(values, value)
+			pointIdx = append(pointIdx, idx)
+		}
+	}
+	confidences := make(map[int]float64)
+	segment(values, 0, len(values), options, confidences)
+
+	indexes := make([]int, 0, len(confidences))
+	for idx := range confidences {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	// means are taken between neighbouring changes
+	bounds := append(append([]int{0}, indexes...), len(values))
+	for i, idx := range indexes {
+		before, after := stats.Mean(values[bounds[i]:idx]), stats.Mean(values[idx:bounds[i+2]])
+		point := trend.Points[pointIdx[idx]]
+		change := ChangePoint{
+			Key:        trend.Key,
+			Unit:       unit,
+			Index:      pointIdx[idx],
+			Timestamp:  point.Timestamp,
+			Commit:     point.Commit,
+			Before:     before,
+			After:      after,
+			Change:     stats.RelativeChange(before, after),
+			Confidence: confidences[idx],
+		}
+		change.Regression = (after > before) != bench.HigherIsBetter(unit)
+		changes = append(changes, change)
+	}
+	return
+}
+
+// ChangePoints detect change points of every metric of every trend
+//
+//	@param trends []Trend
+//	@param options ChangePointOptions
+//	@return changes []ChangePoint in the order of trends and units
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func ChangePoints(trends []Trend, options ChangePointOptions) (changes []ChangePoint) {
+	for idx := range trends {
+		for _, unit := range trends[idx].Units() {
+			changes = append(changes, trends[idx].ChangePoints(unit, options)...)
+		}
+	}
+	return
+}
+
+// segment find the most significant split of values[start:end] and recurse on both sides, confidences are keyed by index of split
+func segment(values []float64, start, end int, options ChangePointOptions, confidences map[int]float64) {
+	candidates := end - start - 2*options.MinRuns + 1
+	if candidates < 1 {
+		return
+	}
+	split, minP := -1, math.Inf(1)
+	for idx := start + options.MinRuns; idx <= end-options.MinRuns; idx++ {
+		before, after := values[start:idx], values[idx:end]
+		if options.Window > 0 && len(before) > options.Window {
+			before = before[len(before)-options.Window:]
+		}
+		if options.Window > 0 && len(after) > options.Window {
+			after = after[:options.Window]
+		}
+		if math.Abs(stats.RelativeChange(stats.Mean(before), stats.Mean(after))) < options.MinChange {
+			continue
+		}
+		result, ok := stats.WelchTTest(before, after)
+		if ok && result.P < minP {
+			split, minP = idx, result.P
+		}
+	}
+	if split < 0 {
+		return
+	}
+	// Bonferroni correction, as the best one of all candidate splits is taken
+	confidence := 1 - math.Min(minP*float64(candidates), 1)
+	if confidence < options.Confidence {
+		return
+	}
+	confidences[split] = confidence
+	segment(values, start, split, options, confidences)
+	segment(values, split, end, options, confidences)
+}
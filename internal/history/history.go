@@ -0,0 +1,126 @@
+// Package history store parsed Benchmark runs in a JSON-lines file and build trends of Benchmark over runs
+//
+//	@update 2026-10-19 18:48:03
+package history
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Entry a Benchmark run in history, stored as one line of JSON
+//
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+type Entry struct {
+	SchemaVersion int         `json:"schema_version"` // same as bench.SchemaVersion
+	Timestamp     time.Time   `json:"timestamp"`      // time of the run
+	Commit        string      `json:"commit,omitempty"`
+	GoVersion     string      `json:"go_version,omitempty"`
+	Command       string      `json:"command,omitempty"`
+	Sets          []bench.Set `json:"sets"`
+}
+
+// NewEntry create a history entry of a run, metadata is taken from the sets when they are recorded by 'run'
+//
+//	@param sets []bench.Set
+//	@param timestamp time.Time zero for the date recorded in sets, or now
+//	@return entry Entry
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+func NewEntry(sets []bench.Set, timestamp time.Time) (entry Entry) {
+	entry = Entry{SchemaVersion: bench.SchemaVersion, Timestamp: timestamp, Sets: sets}
+	for _, set := range sets {
+		if entry.Commit == "" {
+			entry.Commit = set.Commit
+		}
+		if entry.GoVersion == "" {
+			entry.GoVersion = set.GoVersion
+		}
+		if entry.Command == "" {
+			entry.Command = set.Command
+		}
+		if entry.Timestamp.IsZero() && set.Date != "" {
+			if date, err := time.Parse(time.RFC3339, set.Date); err == nil {
+				entry.Timestamp = date
+			}
+		}
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	return
+}
+
+// Append append an entry to the history file, the file is created when it does not exist
+//
+//	@param path string
+//	@param entry Entry
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+func Append(path string, entry Entry) (err error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("[Append] error when marshal history entry: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("[Append] error when open history file: %w", err)
+	}
+	// one write per entry, so that a line is never interleaved with others
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("[Append] error when write history file: %w", err)
+	}
+	return f.Close()
+}
+
+// Load load all entries of a history file, sorted by timestamp
+//
+//	@param path string
+//	@return entries []Entry
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+func Load(path string) (entries []Entry, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("[Load] error when open history file: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			var entry Entry
+			if err = json.Unmarshal([]byte(line), &entry); err != nil {
+				return nil, fmt.Errorf("[Load] %s:%d: invalid history entry: %w", path, lineNum, err)
+			}
+			if entry.SchemaVersion > bench.SchemaVersion {
+				return nil, fmt.Errorf("[Load] %s:%d: schema version %d is newer than the supported version %d, please upgrade benchvisual",
+					path, lineNum, entry.SchemaVersion, bench.SchemaVersion)
+			}
+			entries = append(entries, entry)
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		} else if readErr != nil {
+			return nil, fmt.Errorf("[Load] error when read history file: %w", readErr)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+	return
+}
@@ -0,0 +1,150 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func newSets(commit string, nsPerOps ...float64) []bench.Set {
+	benchmarks := make(bench.BenchmarkList, 0, len(nsPerOps))
+	for _, nsPerOp := range nsPerOps {
+		benchmarks = append(benchmarks, bench.Benchmark{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", NsPerOp: nsPerOp, Mem: bench.Mem{AllocsPerOp: 1}})
+	}
+	return []bench.Set{{Pkg: "demo", Commit: commit, Targets: map[string]bench.BenchmarkList{"Fib": benchmarks}}}
+}
+
+func TestHistory(t *testing.T) {
+	convey.Convey("Given a history file", t, func() {
+		path := filepath.Join(t.TempDir(), "history.jsonl")
+		start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+		convey.Convey("Append runs and load them back in time order", func() {
+			convey.So(Append(path, NewEntry(newSets("bbb", 200), start.Add(time.Hour))), convey.ShouldBeNil)
+			convey.So(Append(path, NewEntry(newSets("aaa", 100, 300), start)), convey.ShouldBeNil)
+
+			entries, err := Load(path)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(entries, convey.ShouldHaveLength, 2)
+			convey.So(entries[0].Commit, convey.ShouldEqual, "aaa")
+			convey.So(entries[0].SchemaVersion, convey.ShouldEqual, bench.SchemaVersion)
+			convey.So(entries[1].Timestamp.Equal(start.Add(time.Hour)), convey.ShouldBeTrue)
+
+			convey.Convey("Build trends of every Benchmark, samples of a run are averaged", func() {
+				trends := Trends(entries)
+				convey.So(trends, convey.ShouldHaveLength, 1)
+				convey.So(trends[0].Key, convey.ShouldResemble, Key{Pkg: "demo", Target: "Fib", Scenario: "10"})
+				convey.So(trends[0].Values("ns/op"), convey.ShouldResemble, []float64{200, 200})
+				convey.So(trends[0].Points[0].Samples, convey.ShouldEqual, 2)
+				convey.So(trends[0].Units(), convey.ShouldResemble, []string{"ns/op", "allocs/op"})
+			})
+		})
+
+		convey.Convey("Fail on a broken line", func() {
+			convey.So(os.WriteFile(path, []byte("{}\nnot json\n"), 0o644), convey.ShouldBeNil)
+			_, err := Load(path)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "history.jsonl:2")
+		})
+
+		convey.Convey("Take timestamp from the date recorded in sets", func() {
+			sets := newSets("ccc", 100)
+			sets[0].Date = start.Format(time.RFC3339)
+			convey.So(NewEntry(sets, time.Time{}).Timestamp.Equal(start), convey.ShouldBeTrue)
+		})
+	})
+}
+
+func newTrend(unit string, values ...float64) *Trend {
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	trend := &Trend{Key: Key{Pkg: "demo", Target: "Fib", Scenario: "10"}}
+	for idx, value := range values {
+		trend.Points = append(trend.Points, Point{
+			Timestamp: start.Add(time.Duration(idx) * time.Hour),
+			Commit:    fmt.Sprintf("c%d", idx),
+			Samples:   1,
+			Metrics:   map[string]float64{unit: value},
+		})
+	}
+	return trend
+}
+
+func TestChangePoints(t *testing.T) {
+	convey.Convey("Detect change points in trends", t, func() {
+		convey.Convey("Report the first bad commit of a shift", func() {
+			trend := newTrend("ns/op", 101, 99, 100, 102, 98, 100, 131, 129, 130, 128, 132, 130)
+			changes := trend.ChangePoints("ns/op", DefaultChangePointOptions)
+			convey.So(changes, convey.ShouldHaveLength, 1)
+			convey.So(changes[0].Index, convey.ShouldEqual, 6)
+			convey.So(changes[0].Commit, convey.ShouldEqual, "c6")
+			convey.So(changes[0].Before, convey.ShouldEqual, 100)
+			convey.So(changes[0].After, convey.ShouldEqual, 130)
+			convey.So(changes[0].Change, convey.ShouldAlmostEqual, 0.3, 1e-9)
+			convey.So(changes[0].Confidence, convey.ShouldBeGreaterThan, 0.99)
+			convey.So(changes[0].Regression, convey.ShouldBeTrue)
+			convey.So(changes[0].String(), convey.ShouldContainSubstring, "+30.00% (100 -> 130) since c6")
+		})
+
+		convey.Convey("Find every shift", func() {
+			trend := newTrend("ns/op", 100, 101, 99, 100, 150, 151, 149, 150, 152, 148, 150, 150, 100, 99, 101, 100)
+			changes := trend.ChangePoints("ns/op", DefaultChangePointOptions)
+			convey.So(changes, convey.ShouldHaveLength, 2)
+			convey.So(changes[0].Index, convey.ShouldEqual, 4)
+			convey.So(changes[1].Index, convey.ShouldEqual, 12)
+			convey.So(changes[1].Regression, convey.ShouldBeFalse)
+		})
+
+		convey.Convey("Do not alert on a single noisy run, a change too small or too few runs", func() {
+			convey.So(newTrend("ns/op", 100, 101, 99, 100, 180, 100, 101, 99, 100).ChangePoints("ns/op", DefaultChangePointOptions), convey.ShouldBeEmpty)
+			convey.So(newTrend("ns/op", 100, 100, 100, 100, 101, 101, 101, 101).ChangePoints("ns/op", DefaultChangePointOptions), convey.ShouldBeEmpty)
+			convey.So(newTrend("ns/op", 100, 100, 200, 200).ChangePoints("ns/op", DefaultChangePointOptions), convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("Higher is better for throughput", func() {
+			changes := ChangePoints([]Trend{*newTrend("MB/s", 50, 51, 49, 50, 40, 41, 39, 40)}, DefaultChangePointOptions)
+			convey.So(changes, convey.ShouldHaveLength, 1)
+			convey.So(changes[0].Unit, convey.ShouldEqual, "MB/s")
+			convey.So(changes[0].Regression, convey.ShouldBeTrue)
+		})
+
+		convey.Convey("Segment only over runs reporting a metric", func() {
+			// hits is reported from run 5 on, and B/op since -benchmem is added at run 5
+			var entries []Entry
+			for idx, nsPerOp := range []float64{100, 101, 99, 100, 101, 99, 100, 101, 99, 100} {
+				benchmark := bench.Benchmark{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", NsPerOp: nsPerOp}
+				if idx >= 4 {
+					benchmark.CustomMetrics = map[string]float64{"hits": 50 + float64(idx%2)}
+					benchmark.Mem = bench.Mem{BytesPerOp: 48, Benchmem: true}
+				}
+				entries = append(entries, Entry{
+					Timestamp: time.Unix(int64(idx), 0),
+					Commit:    fmt.Sprintf("c%d", idx),
+					Sets:      []bench.Set{{Pkg: "demo", Targets: map[string]bench.BenchmarkList{"Fib": {benchmark}}}},
+				})
+			}
+			trends := Trends(entries)
+			convey.So(trends, convey.ShouldHaveLength, 1)
+			convey.So(trends[0].Units(), convey.ShouldResemble, []string{"ns/op", "B/op", "hits"})
+			convey.So(trends[0].Points[3].Metrics, convey.ShouldNotContainKey, "hits")
+			convey.So(trends[0].Points[3].Metrics, convey.ShouldNotContainKey, "B/op")
+			convey.So(math.IsNaN(trends[0].Values("hits")[3]), convey.ShouldBeTrue)
+			convey.So(trends[0].Values("hits")[4], convey.ShouldEqual, 50)
+			convey.So(ChangePoints(trends, DefaultChangePointOptions), convey.ShouldBeEmpty)
+
+			// a shift among the runs reporting the metric is still found, at the index of its point
+			for idx := 7; idx < 10; idx++ {
+				entries[idx].Sets[0].Targets["Fib"][0].CustomMetrics["hits"] = 80 + float64(idx%2)
+			}
+			changes := Trends(entries)[0].ChangePoints("hits", ChangePointOptions{Confidence: 0.99, MinRuns: 3, MinChange: 0.02})
+			convey.So(changes, convey.ShouldHaveLength, 1)
+			convey.So(changes[0].Index, convey.ShouldEqual, 7)
+			convey.So(changes[0].Commit, convey.ShouldEqual, "c7")
+		})
+	})
+}
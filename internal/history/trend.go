@@ -0,0 +1,142 @@
+package history
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+)
+
+// Key identify a Benchmark across runs
+type Key struct {
+	Pkg      string
+	Target   string
+	Scenario string
+}
+
+// String readable form of the key, e.g. pkg Target/Scenario
+//
+//	@receiver key Key
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+func (key Key) String() string {
+	if key.Scenario == "" {
+		return key.Pkg + " " + key.Target
+	}
+	return key.Pkg + " " + key.Target + "/" + key.Scenario
+}
+
+// Point a Benchmark in a run, samples of the run(e.g. by -count) are averaged
+type Point struct {
+	Timestamp time.Time
+	Commit    string
+	Samples   int // number of samples averaged
+	// Metrics unit -> mean value of the samples reporting the metric, e.g. ns/op -> 358,
+	// metrics not reported in the run(e.g. B/op without -benchmem, or a custom metric added later) are absent
+	Metrics map[string]float64
+}
+
+// Trend points of a Benchmark over runs, sorted by time
+type Trend struct {
+	Key    Key
+	Points []Point
+}
+
+// Units units of metrics in the trend, builtin metrics first, then custom metrics in order,
+// metrics which are zero in all points(e.g. B/op without -benchmem) are left out
+//
+//	@receiver trend *Trend
+//	@return units []string
+//	@author kevineluo
+//	@update 2026-10-19 18:48:03
+func (trend *Trend) Units() (units []string) {
+	nonZero := make(map[string]bool)
+	for _, point := range trend.Points {
+		for unit, value := range point.Metrics {
+			nonZero[unit] = nonZero[unit] || value != 0
+		}
+	}
+	return bench.SortUnits(nonZero)
+}
+
+// Values values of a metric over the points of trend, NaN for points where the metric is not reported
+//
+//	@receiver trend *Trend
+//	@param unit string
+//	@return values []float64
+//	@author kevineluo
+//	@update 2026-10-19 23:58:40
+func (trend *Trend) Values(unit string) (values []float64) {
+	values = make([]float64, len(trend.Points))
+	for idx, point := range trend.Points {
+		value, ok := point.Metrics[unit]
+		if !ok {
+			value = math.NaN()
+		}
+		values[idx] = value
+	}
+	return
+}
+
+// Trends build trends of every Benchmark(keyed by package, target and scenario) from history entries sorted by time,
+// a metric of a point is averaged over the samples reporting it
+//
+//	@param entries []Entry
+//	@return trends []Trend sorted by key
+//	@author kevineluo
+//	@update 2026-10-19 23:58:40
+func Trends(entries []Entry) (trends []Trend) {
+	trendIdx := make(map[Key]int)
+	for _, entry := range entries {
+		// sum of metrics of every Benchmark in this run, and the number of samples reporting each metric
+		sums, counts := make(map[Key]*Point), make(map[Key]map[string]int)
+		var keys []Key
+		for _, set := range entry.Sets {
+			for _, benchmarks := range set.Targets {
+				for idx := range benchmarks {
+					benchmark := &benchmarks[idx]
+					key := Key{Pkg: set.Pkg, Target: benchmark.Target, Scenario: benchmark.Scenario}
+					point, ok := sums[key]
+					if !ok {
+						point = &Point{Timestamp: entry.Timestamp, Commit: entry.Commit, Metrics: make(map[string]float64)}
+						sums[key], counts[key] = point, make(map[string]int)
+						keys = append(keys, key)
+					}
+					point.Samples++
+					for unit, value := range benchmark.Metrics() {
+						if benchmark.Reports(unit) {
+							point.Metrics[unit] += value
+							counts[key][unit]++
+						}
+					}
+				}
+			}
+		}
+		for _, key := range keys {
+			point := sums[key]
+			for unit := range point.Metrics {
+				point.Metrics[unit] /= float64(counts[key][unit])
+			}
+			idx, ok := trendIdx[key]
+			if !ok {
+				idx = len(trends)
+				trendIdx[key] = idx
+				trends = append(trends, Trend{Key: key})
+			}
+			trends[idx].Points = append(trends[idx].Points, *point)
+		}
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		a, b := trends[i].Key, trends[j].Key
+		if a.Pkg != b.Pkg {
+			return a.Pkg < b.Pkg
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Scenario < b.Scenario
+	})
+	return
+}
@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Worktree a temporary detached git worktree of a repository, so that commits are checked out without touching the working directory
+type Worktree struct {
+	Repo string // directory of the repository
+	Dir  string // directory of the worktree
+}
+
+// NewWorktree add a detached worktree of the repository in a temporary directory, checked out at rev
+//
+//	@param ctx context.Context
+//	@param repo string directory in the repository
+//	@param rev string
+//	@return worktree *Worktree
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func NewWorktree(ctx context.Context, repo string, rev string) (worktree *Worktree, err error) {
+	dir, err := os.MkdirTemp("", "benchvisual-worktree-")
+	if err != nil {
+		return nil, fmt.Errorf("[NewWorktree] error when create worktree directory: %w", err)
+	}
+	if _, err = Git(ctx, repo, "worktree", "add", "--detach", dir, rev); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Worktree{Repo: repo, Dir: dir}, nil
+}
+
+// Checkout check out rev in the worktree
+//
+//	@receiver worktree *Worktree
+//	@param ctx context.Context
+//	@param rev string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func (worktree *Worktree) Checkout(ctx context.Context, rev string) (err error) {
+	_, err = Git(ctx, worktree.Dir, "checkout", "--detach", "--quiet", rev)
+	return
+}
+
+// Remove remove the worktree and its directory
+//
+//	@receiver worktree *Worktree
+//	@param ctx context.Context
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func (worktree *Worktree) Remove(ctx context.Context) (err error) {
+	_, err = Git(ctx, worktree.Repo, "worktree", "remove", "--force", worktree.Dir)
+	return errors.Join(err, os.RemoveAll(worktree.Dir))
+}
+
+// Verdict verdict of a commit in git bisect
+type Verdict string
+
+const (
+	VerdictGood Verdict = "good"
+	VerdictBad  Verdict = "bad"
+	VerdictSkip Verdict = "skip" // the commit can not be tested, e.g. it does not build
+)
+
+// Judge tell the verdict of the commit checked out in the bisected directory
+type Judge func(ctx context.Context, commit string) (verdict Verdict, err error)
+
+// Bisect drive 'git bisect' in dir between good and bad revisions, every commit checked out by git is judged by judge,
+// bisect is reset at the end
+//
+//	@param ctx context.Context
+//	@param dir string directory of the repository(or a worktree of it) to bisect in
+//	@param good string
+//	@param bad string
+//	@param judge Judge
+//	@return firstBad []string the first bad commit, or every candidate of it when only skipped commits are left to test
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 00:12:35
+func Bisect(ctx context.Context, dir string, good string, bad string, judge Judge) (firstBad []string, err error) {
+	output, err := Git(ctx, dir, "bisect", "start", bad, good)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// reset with a fresh context, so that bisect is not left behind when ctx is canceled
+		if _, resetErr := Git(context.Background(), dir, "bisect", "reset"); resetErr != nil {
+			err = errors.Join(err, resetErr)
+		}
+	}()
+
+	for {
+		if commit, ok := parseFirstBad(output); ok {
+			return []string{commit}, nil
+		}
+		commit, err := Git(ctx, dir, "rev-parse", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		verdict, err := judge(ctx, commit)
+		if err != nil {
+			return nil, err
+		}
+		output, err = bisectStep(ctx, dir, verdict)
+		if candidates, ok := parseCandidates(output); ok {
+			return candidates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// bisectStep run 'git bisect <verdict>', stdout is returned even when git fails,
+// as git bisect exits with status 2 when only skipped commits are left to test
+func bisectStep(ctx context.Context, dir string, verdict Verdict) (output string, err error) {
+	cmd := exec.CommandContext(ctx, "git", "bisect", string(verdict))
+	cmd.Dir = dir
+	stderr := new(strings.Builder)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		err = fmt.Errorf("[Git] error when run 'git bisect %s': %w: %s", verdict, err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), err
+}
+
+// parseFirstBad find the first bad commit in output of 'git bisect', e.g. '<commit> is the first bad commit'
+func parseFirstBad(output string) (commit string, ok bool) {
+	for _, line := range strings.Split(output, "\n") {
+		if commit, ok = strings.CutSuffix(strings.TrimSpace(line), " is the first bad commit"); ok {
+			return commit, true
+		}
+	}
+	return "", false
+}
+
+// parseCandidates find candidates of the first bad commit in output of 'git bisect' when only skipped commits are left, e.g.
+//
+//	There are only 'skip'ped commits left to test.
+//	The first bad commit could be any of:
+//	<commit>
+//	<commit>
+//	We cannot bisect more!
+func parseCandidates(output string) (candidates []string, ok bool) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasSuffix(line, "commit could be any of:"):
+			ok = true
+		case ok && isCommit(line):
+			candidates = append(candidates, line)
+		case ok && line != "":
+			return candidates, len(candidates) > 0
+		}
+	}
+	return candidates, ok && len(candidates) > 0
+}
+
+// isCommit whether s is a full hexadecimal object name
+func isCommit(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
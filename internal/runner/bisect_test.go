@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+// newRepo create a git repository with a commit for every value written to the file 'value'
+func newRepo(t *testing.T, values ...int) (repo string, commits []string) {
+	repo = t.TempDir()
+	ctx := context.Background()
+	git := func(args ...string) string {
+		output, err := Git(ctx, repo, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output
+	}
+	git("init", "--quiet")
+	for _, value := range values {
+		if err := os.WriteFile(filepath.Join(repo, "value"), []byte(strconv.Itoa(value)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "value")
+		git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "value "+strconv.Itoa(value))
+		commits = append(commits, git("rev-parse", "HEAD"))
+	}
+	return
+}
+
+func TestBisect(t *testing.T) {
+	convey.Convey("Given a repository where a value grows at a commit", t, func() {
+		ctx := context.Background()
+		repo, commits := newRepo(t, 1, 1, 1, 1, 1, 9, 9, 9, 9, 9)
+
+		convey.Convey("Bisect in a worktree to the first bad commit", func() {
+			worktree, err := NewWorktree(ctx, repo, commits[0])
+			convey.So(err, convey.ShouldBeNil)
+			defer worktree.Remove(ctx)
+
+			judged := 0
+			firstBad, err := Bisect(ctx, worktree.Dir, commits[0], commits[len(commits)-1], func(ctx context.Context, commit string) (Verdict, error) {
+				judged++
+				content, err := os.ReadFile(filepath.Join(worktree.Dir, "value"))
+				if err != nil {
+					return "", err
+				}
+				if string(content) == "9" {
+					return VerdictBad, nil
+				}
+				return VerdictGood, nil
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(firstBad, convey.ShouldResemble, []string{commits[5]})
+			convey.So(judged, convey.ShouldBeLessThanOrEqualTo, 4)
+
+			convey.Convey("Remove the worktree", func() {
+				convey.So(worktree.Remove(ctx), convey.ShouldBeNil)
+				_, err := os.Stat(worktree.Dir)
+				convey.So(os.IsNotExist(err), convey.ShouldBeTrue)
+			})
+		})
+
+		convey.Convey("Return every candidate when only skipped commits are left", func() {
+			worktree, err := NewWorktree(ctx, repo, commits[0])
+			convey.So(err, convey.ShouldBeNil)
+			defer worktree.Remove(ctx)
+
+			// commits with the grown value can not be tested, except the bad revision
+			firstBad, err := Bisect(ctx, worktree.Dir, commits[0], commits[len(commits)-1], func(ctx context.Context, commit string) (Verdict, error) {
+				content, err := os.ReadFile(filepath.Join(worktree.Dir, "value"))
+				if err != nil {
+					return "", err
+				}
+				if string(content) == "9" {
+					return VerdictSkip, nil
+				}
+				return VerdictGood, nil
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(firstBad, convey.ShouldHaveLength, 5)
+			for _, commit := range commits[5:] {
+				convey.So(firstBad, convey.ShouldContain, commit)
+			}
+		})
+
+		convey.Convey("Check out revisions in the worktree", func() {
+			worktree, err := NewWorktree(ctx, repo, commits[0])
+			convey.So(err, convey.ShouldBeNil)
+			defer worktree.Remove(ctx)
+			convey.So(worktree.Checkout(ctx, commits[9]), convey.ShouldBeNil)
+			commit, err := Git(ctx, worktree.Dir, "rev-parse", "HEAD")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(commit, convey.ShouldEqual, commits[9])
+		})
+	})
+}
+
+func TestParseCandidates(t *testing.T) {
+	convey.Convey("Parse candidates of the first bad commit from output of git bisect", t, func() {
+		commitA, commitB := strings.Repeat("a", 40), strings.Repeat("b", 40)
+		candidates, ok := parseCandidates("There are only 'skip'ped commits left to test.\nThe first bad commit could be any of:\n" +
+			commitA + "\n" + commitB + "\nWe cannot bisect more!\n")
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(candidates, convey.ShouldResemble, []string{commitA, commitB})
+
+		_, ok = parseCandidates("Bisecting: 2 revisions left to test after this (roughly 1 step)\n")
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+}
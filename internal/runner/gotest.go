@@ -0,0 +1,185 @@
+// Package runner run Golang Benchmark with the go tool
+//
+//	@update 2026-10-19 13:02:15
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// DefaultBenchFlags go test flags used when no flag is given, run all Benchmarks without tests
+var DefaultBenchFlags = []string{"-run", "^$", "-bench", ".", "-benchmem"}
+
+// GoTest a 'go test' invocation
+type GoTest struct {
+	GoBin string   // path of the go tool
+	Dir   string   // working directory, current directory when empty
+	Pkgs  []string // packages to benchmark
+	Flags []string // go test flags
+}
+
+// NewGoTest create a 'go test' invocation, return error when go tool is missing
+//
+//	@param pkgs []string packages to benchmark, './...' when empty
+//	@param flags []string go test flags, DefaultBenchFlags when empty
+//	@return goTest *GoTest
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func NewGoTest(pkgs []string, flags []string) (goTest *GoTest, err error) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, fmt.Errorf("[NewGoTest] go tool not found in $PATH, which is required to run Benchmark: %w", err)
+	}
+	if len(pkgs) == 0 {
+		pkgs = []string{"./..."}
+	}
+	if len(flags) == 0 {
+		flags = DefaultBenchFlags
+	}
+	return &GoTest{GoBin: goBin, Pkgs: pkgs, Flags: flags}, nil
+}
+
+// Args arguments passed to go tool
+//
+//	@receiver goTest *GoTest
+//	@return []string
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func (goTest *GoTest) Args() []string {
+	return append(append([]string{"test"}, goTest.Pkgs...), goTest.Flags...)
+}
+
+// CommandLine the exact command line to run, with shell quoting
+//
+//	@receiver goTest *GoTest
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func (goTest *GoTest) CommandLine() string {
+	return strings.Join(append([]string{"go"}, QuoteArgs(goTest.Args())...), " ")
+}
+
+// Run run go test, stream its stdout and stderr to the given writers, and forward SIGINT / SIGTERM to it
+//
+//	@receiver goTest *GoTest
+//	@param ctx context.Context go test is killed when ctx is done
+//	@param stdout io.Writer
+//	@param stderr io.Writer
+//	@return err error *exec.ExitError when go test exit with non-zero status
+//	@author kevineluo
+//	@update 2026-10-20 01:46:10
+func (goTest *GoTest) Run(ctx context.Context, stdout io.Writer, stderr io.Writer) (err error) {
+	cmd := exec.Command(goTest.GoBin, goTest.Args()...)
+	cmd.Dir = goTest.Dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// catch signals before go test starts, so that it is never left running without us
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("[GoTest.Run] error when start %q: %w", goTest.CommandLine(), err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	for {
+		select {
+		case sig := <-signals:
+			// let go test clean up and report by itself
+			_ = cmd.Process.Signal(sig)
+		case <-ctx.Done():
+			_ = cmd.Process.Kill()
+			<-done
+			return ctx.Err()
+		case err = <-done:
+			var exitErr *exec.ExitError
+			if err != nil && !errors.As(err, &exitErr) {
+				err = fmt.Errorf("[GoTest.Run] error when wait %q: %w", goTest.CommandLine(), err)
+			}
+			return err
+		}
+	}
+}
+
+// GoVersion version of the go tool, e.g. go1.20.3
+//
+//	@param ctx context.Context
+//	@param goBin string
+//	@return version string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func GoVersion(ctx context.Context, goBin string) (version string, err error) {
+	output, err := exec.CommandContext(ctx, goBin, "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("[GoVersion] error when get go version: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GitCommit commit of HEAD in the given directory, with a '-dirty' suffix when there are uncommitted changes
+//
+//	@param ctx context.Context
+//	@param dir string
+//	@return commit string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func GitCommit(ctx context.Context, dir string) (commit string, err error) {
+	output, err := Git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	commit = output
+	if status, err := Git(ctx, dir, "status", "--porcelain", "--untracked-files=no"); err == nil && status != "" {
+		commit += "-dirty"
+	}
+	return
+}
+
+// Git run a git command in the given directory, return its trimmed stdout
+//
+//	@param ctx context.Context
+//	@param dir string
+//	@param args ...string
+//	@return output string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func Git(ctx context.Context, dir string, args ...string) (output string, err error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	stderr := new(strings.Builder)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("[Git] error when run 'git %s': %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// QuoteArgs quote arguments which contain shell special characters with single quotes
+//
+//	@param args []string
+//	@return quoted []string
+//	@author kevineluo
+//	@update 2026-10-19 13:02:15
+func QuoteArgs(args []string) (quoted []string) {
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`!*?[]{}()<>|&;^#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestCommandLine(t *testing.T) {
+	convey.Convey("Given a go test invocation", t, func() {
+		goTest := &GoTest{GoBin: "/usr/local/go/bin/go", Pkgs: []string{"./..."}, Flags: append(DefaultBenchFlags, "-count", "5")}
+		convey.Convey("Build its arguments and command line", func() {
+			convey.So(goTest.Args(), convey.ShouldResemble, []string{"test", "./...", "-run", "^$", "-bench", ".", "-benchmem", "-count", "5"})
+			convey.So(goTest.CommandLine(), convey.ShouldEqual, "go test ./... -run '^$' -bench . -benchmem -count 5")
+		})
+		convey.Convey("Quote arguments with shell special characters", func() {
+			convey.So(QuoteArgs([]string{"", "it's", "a b", "plain"}), convey.ShouldResemble, []string{"''", `'it'\''s'`, "'a b'", "plain"})
+		})
+	})
+}
+
+func TestTestBinaryFlags(t *testing.T) {
+	convey.Convey("Convert go test flags to flags of test binaries", t, func() {
+		convey.So(TestBinaryFlags([]string{"-benchtime", "1s", "--cpu=1,2", "-test.v", "-"}), convey.ShouldResemble,
+			[]string{"-test.benchtime", "1s", "-test.cpu=1,2", "-test.v", "-"})
+	})
+}
+
+func TestGoTestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stand-in go tool is a shell script")
+	}
+	convey.Convey("Given a stand-in go tool failing with exit status 3", t, func() {
+		goBin := filepath.Join(t.TempDir(), "go")
+		err := os.WriteFile(goBin, []byte("#!/bin/sh\necho \"$@\"\necho failed >&2\nexit 3\n"), 0o755)
+		convey.So(err, convey.ShouldBeNil)
+
+		convey.Convey("Run streams its output and returns its exit status", func() {
+			goTest := &GoTest{GoBin: goBin, Pkgs: []string{"./..."}, Flags: []string{"-bench", "."}}
+			stdout, stderr := new(strings.Builder), new(strings.Builder)
+			err := goTest.Run(context.Background(), stdout, stderr)
+			var exitErr *exec.ExitError
+			convey.So(errors.As(err, &exitErr), convey.ShouldBeTrue)
+			convey.So(exitErr.ExitCode(), convey.ShouldEqual, 3)
+			convey.So(stdout.String(), convey.ShouldEqual, "test ./... -bench .\n")
+			convey.So(stderr.String(), convey.ShouldEqual, "failed\n")
+		})
+	})
+}
+
+func TestNewGoTestWithoutGo(t *testing.T) {
+	convey.Convey("Given a $PATH without go tool", t, func() {
+		t.Setenv("PATH", t.TempDir())
+		convey.Convey("NewGoTest fails", func() {
+			goTest, err := NewGoTest(nil, nil)
+			convey.So(goTest, convey.ShouldBeNil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "go tool not found")
+		})
+	})
+}
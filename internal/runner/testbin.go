@@ -0,0 +1,164 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// boolBuildFlags build flags of go test without value, which are passed to 'go test -c' instead of test binaries
+var boolBuildFlags = map[string]bool{
+	"a": true, "n": true, "x": true, "work": true, "race": true, "msan": true, "asan": true, "cover": true,
+	"linkshared": true, "modcacherw": true, "trimpath": true, "buildvcs": true,
+}
+
+// valueBuildFlags build flags of go test with a value, which are passed to 'go test -c' instead of test binaries
+var valueBuildFlags = map[string]bool{
+	"p": true, "covermode": true, "coverpkg": true, "asmflags": true, "buildmode": true, "compiler": true, "gccgoflags": true,
+	"gcflags": true, "installsuffix": true, "ldflags": true, "mod": true, "modfile": true, "overlay": true, "pgo": true,
+	"pkgdir": true, "tags": true, "toolexec": true, "vet": true,
+}
+
+// TestBinary a test binary of a package built by 'go test -c'
+type TestBinary struct {
+	Pkg  string // import path of the package
+	Dir  string // directory of the package, where the binary runs like 'go test' does
+	Path string // path of the binary
+}
+
+// BuildTestBinaries build test binaries of the packages with 'go test -c', packages without test files are left out
+//
+//	@param ctx context.Context
+//	@param goBin string path of the go tool
+//	@param dir string working directory, where packages are resolved
+//	@param pkgs []string packages, './...' when empty
+//	@param buildFlags []string build flags passed to 'go list' and 'go test -c', e.g. -tags, see SplitBuildFlags
+//	@param outDir string directory to put binaries in
+//	@return binaries []TestBinary
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-20 00:38:52
+func BuildTestBinaries(ctx context.Context, goBin string, dir string, pkgs []string, buildFlags []string, outDir string) (binaries []TestBinary, err error) {
+	if len(pkgs) == 0 {
+		pkgs = []string{"./..."}
+	}
+	listArgs := append(append([]string{"list", "-f", `{{if or .TestGoFiles .XTestGoFiles}}{{.ImportPath}}{{"\t"}}{{.Dir}}{{end}}`}, buildFlags...), pkgs...)
+	output, err := goCommand(ctx, goBin, dir, listArgs...)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		pkg, pkgDir, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		binary := TestBinary{Pkg: pkg, Dir: pkgDir, Path: filepath.Join(outDir, strings.ReplaceAll(pkg, "/", "_")+".test")}
+		buildArgs := append(append([]string{"test", "-c", "-o", binary.Path}, buildFlags...), pkg)
+		if _, err = goCommand(ctx, goBin, dir, buildArgs...); err != nil {
+			return nil, err
+		}
+		binaries = append(binaries, binary)
+	}
+	return
+}
+
+// Run run the test binary in the directory of its package
+//
+//	@receiver binary *TestBinary
+//	@param ctx context.Context the binary is killed when ctx is done
+//	@param args []string flags of the test binary, e.g. -test.bench=.
+//	@param stdout io.Writer
+//	@param stderr io.Writer
+//	@return err error *exec.ExitError when the binary exit with non-zero status
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func (binary *TestBinary) Run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) (err error) {
+	cmd := exec.CommandContext(ctx, binary.Path, args...)
+	cmd.Dir = binary.Dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		err = fmt.Errorf("[TestBinary.Run] error when run test binary of %s: %w", binary.Pkg, err)
+	}
+	return
+}
+
+// SplitBuildFlags split build flags(e.g. -tags, -race, -gcflags) off go test flags, which apply to 'go test -c'
+// rather than test binaries, the value of a build flag given as the next argument goes with it
+//
+//	@param flags []string go test flags
+//	@return buildFlags []string
+//	@return testFlags []string
+//	@author kevineluo
+//	@update 2026-10-20 00:38:52
+func SplitBuildFlags(flags []string) (buildFlags []string, testFlags []string) {
+	for idx := 0; idx < len(flags); idx++ {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(flags[idx], "-"), "=")
+		switch {
+		case !strings.HasPrefix(flags[idx], "-"):
+			testFlags = append(testFlags, flags[idx])
+		case boolBuildFlags[name]:
+			buildFlags = append(buildFlags, flags[idx])
+		case valueBuildFlags[name]:
+			buildFlags = append(buildFlags, flags[idx])
+			if !hasValue && idx+1 < len(flags) {
+				idx++
+				buildFlags = append(buildFlags, flags[idx])
+			}
+		default:
+			testFlags = append(testFlags, flags[idx])
+		}
+	}
+	return
+}
+
+// FlagName name of a command line flag without dashes, value and 'test.' prefix, e.g. '--test.count=5' -> 'count',
+// empty when the argument is not a flag
+//
+//	@param arg string
+//	@return string
+//	@author kevineluo
+//	@update 2026-10-20 00:38:52
+func FlagName(arg string) string {
+	if !strings.HasPrefix(arg, "-") {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return strings.TrimPrefix(name, "test.")
+}
+
+// TestBinaryFlags convert go test flags to flags of test binaries, e.g. '-benchtime 1s' -> '-test.benchtime 1s',
+// values and flags already prefixed by 'test.' are kept
+//
+//	@param flags []string
+//	@return converted []string
+//	@author kevineluo
+//	@update 2026-10-19 19:58:21
+func TestBinaryFlags(flags []string) (converted []string) {
+	for _, flag := range flags {
+		if name := strings.TrimLeft(flag, "-"); strings.HasPrefix(flag, "-") && name != "" && !strings.HasPrefix(name, "test.") {
+			flag = "-test." + name
+		}
+		converted = append(converted, flag)
+	}
+	return
+}
+
+// goCommand run the go tool in dir, return its trimmed stdout, stderr is included in the error
+func goCommand(ctx context.Context, goBin string, dir string, args ...string) (output string, err error) {
+	cmd := exec.CommandContext(ctx, goBin, args...)
+	cmd.Dir = dir
+	stderr := new(strings.Builder)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("[goCommand] error when run 'go %s': %w: %s", strings.Join(QuoteArgs(args), " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestSplitBuildFlags(t *testing.T) {
+	convey.Convey("Split build flags off go test flags", t, func() {
+		buildFlags, testFlags := SplitBuildFlags([]string{"-tags", "integration", "-benchtime", "1s", "-race", "--gcflags=-N -l", "-cpu", "1,2", "-test.v"})
+		convey.So(buildFlags, convey.ShouldResemble, []string{"-tags", "integration", "-race", "--gcflags=-N -l"})
+		convey.So(testFlags, convey.ShouldResemble, []string{"-benchtime", "1s", "-cpu", "1,2", "-test.v"})
+	})
+	convey.Convey("Name flags without dashes, value and 'test.' prefix", t, func() {
+		convey.So(FlagName("--test.count=5"), convey.ShouldEqual, "count")
+		convey.So(FlagName("-count"), convey.ShouldEqual, "count")
+		convey.So(FlagName("5"), convey.ShouldEqual, "")
+	})
+}
+
+func TestBuildTestBinaries(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool is not found")
+	}
+	convey.Convey("Given a module with a tested package, a package tested only with a build tag and an untested package", t, func() {
+		dir := t.TempDir()
+		files := map[string]string{
+			"go.mod":           "module demo\n\ngo 1.20\n",
+			"tested/a.go":      "package tested\n",
+			"tested/a_test.go": "package tested\n\nimport \"testing\"\n\nfunc BenchmarkA(b *testing.B) {}\n",
+			"tagged/b.go":      "package tagged\n",
+			"tagged/b_test.go": "//go:build integration\n\npackage tagged\n\nimport \"testing\"\n\nfunc BenchmarkB(b *testing.B) {}\n",
+			"untested/c.go":    "package untested\n",
+		}
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			convey.So(os.MkdirAll(filepath.Dir(path), os.ModePerm), convey.ShouldBeNil)
+			convey.So(os.WriteFile(path, []byte(content), 0o644), convey.ShouldBeNil)
+		}
+		outDir := t.TempDir()
+
+		convey.Convey("Build binaries of packages with tests only", func() {
+			binaries, err := BuildTestBinaries(context.Background(), goBin, dir, nil, nil, outDir)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(binaries, convey.ShouldHaveLength, 1)
+			convey.So(binaries[0].Pkg, convey.ShouldEqual, "demo/tested")
+			convey.So(binaries[0].Path, convey.ShouldEqual, filepath.Join(outDir, "demo_tested.test"))
+
+			stdout := new(strings.Builder)
+			err = binaries[0].Run(context.Background(), []string{"-test.run=^$", "-test.bench=.", "-test.benchtime=1x"}, stdout, stdout)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(stdout.String(), convey.ShouldContainSubstring, "BenchmarkA")
+		})
+		convey.Convey("Build flags are passed to go list and go test -c", func() {
+			binaries, err := BuildTestBinaries(context.Background(), goBin, dir, []string{"./..."}, []string{"-tags", "integration"}, outDir)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(binaries, convey.ShouldHaveLength, 2)
+			convey.So(binaries[0].Pkg, convey.ShouldEqual, "demo/tagged")
+		})
+		convey.Convey("Errors of the go tool are returned", func() {
+			_, err := BuildTestBinaries(context.Background(), goBin, dir, []string{"./missing"}, nil, outDir)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
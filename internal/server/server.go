@@ -0,0 +1,304 @@
+// Package server serve visualized benchmark pages over HTTP, charts are updated live by Server-Sent Events
+//
+//	@update 2026-10-19 14:15:03
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Server hold Benchmark sets parsed so far and serve their pages
+//
+//	@author kevineluo
+//	@update 2026-10-19 14:15:03
+type Server struct {
+	layout visual.Layout
+
+	mu     sync.Mutex
+	sets   []bench.Set
+	setIdx map[string]int // pkg -> index of sets
+
+	subscribersMu sync.Mutex
+	subscribers   map[chan string]struct{}
+}
+
+// NewServer create an empty Server
+//
+//	@param layout visual.Layout layout of charts
+//	@return *Server
+//	@author kevineluo
+//	@update 2026-10-19 17:41:50
+func NewServer(layout visual.Layout) *Server {
+	return &Server{
+		layout:      layout,
+		setIdx:      make(map[string]int),
+		subscribers: make(map[chan string]struct{}),
+	}
+}
+
+// AddBenchmark add a parsed Benchmark of the given set and notify all subscribers, it can be used as bench.BenchmarkHandler
+//
+//	@receiver s *Server
+//	@param set *bench.Set set being parsed, only its metadata is used
+//	@param benchmark *bench.Benchmark
+//	@author kevineluo
+//	@update 2026-10-19 14:15:03
+func (s *Server) AddBenchmark(set *bench.Set, benchmark *bench.Benchmark) {
+	s.mu.Lock()
+	idx, ok := s.setIdx[set.Pkg]
+	if !ok {
+		idx = len(s.sets)
+		s.setIdx[set.Pkg] = idx
+		s.sets = append(s.sets, bench.Set{Targets: make(map[string]bench.BenchmarkList)})
+	}
+	stored := &s.sets[idx]
+	stored.Goos, stored.Goarch, stored.Pkg, stored.CPU = set.Goos, set.Goarch, set.Pkg, set.CPU
+	stored.Targets[benchmark.Target] = append(stored.Targets[benchmark.Target], *benchmark)
+	s.mu.Unlock()
+
+	s.broadcast(set.Pkg)
+}
+
+// Sets copy of Benchmark sets parsed so far
+//
+//	@receiver s *Server
+//	@return sets []bench.Set
+//	@author kevineluo
+//	@update 2026-10-19 14:15:03
+func (s *Server) Sets() (sets []bench.Set) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, set := range s.sets {
+		sets = append(sets, copySet(&set))
+	}
+	return
+}
+
+// Handler HTTP handler of the Server
+//
+//	GET /                index of packages
+//	GET /page?pkg=<pkg>  visualized page of a package
+//	GET /options?pkg=... chart options of a package, keyed by chart id
+//	GET /events          Server-Sent Events, data is the package which got a new Benchmark
+//
+//	@receiver s *Server
+//	@return http.Handler
+//	@author kevineluo
+//	@update 2026-10-19 14:15:03
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/page", s.handlePage)
+	mux.HandleFunc("/options", s.handleOptions)
+	mux.HandleFunc("/events", s.handleEvents)
+	return mux
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Lock()
+	pkgs := make([]string, 0, len(s.sets))
+	for _, set := range s.sets {
+		pkgs = append(pkgs, set.Pkg)
+	}
+	s.mu.Unlock()
+	sort.Strings(pkgs)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, pkgs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	set, ok := s.getSet(r.URL.Query().Get("pkg"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := visual.NewPage(&set, s.layout).Render(buffer); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// inject live update script to the rendered page
+	script := new(strings.Builder)
+	if err := liveScriptTemplate.Execute(script, set.Pkg); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	page := strings.Replace(buffer.String(), "</body>", script.String()+"</body>", 1)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, page)
+}
+
+func (s *Server) handleOptions(w http.ResponseWriter, r *http.Request) {
+	set, ok := s.getSet(r.URL.Query().Get("pkg"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	options := make(map[string]interface{})
+	for _, chart := range visual.NewCharts(&set, s.layout) {
+		chart.Validate()
+		options[chart.ChartID] = chart.JSON()
+	}
+	if s.layout.BoxPlot {
+		for _, chart := range visual.NewBoxPlots(&set, s.layout) {
+			chart.Validate()
+			options[chart.ChartID] = chart.JSON()
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events := s.subscribe()
+	defer s.unsubscribe(events)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case pkg := <-events:
+			fmt.Fprintf(w, "data: %s\n\n", pkg)
+			flusher.Flush()
+		}
+	}
+}
+
+// getSet copy of the set of the given package
+func (s *Server) getSet(pkg string) (set bench.Set, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, ok := s.setIdx[pkg]
+	if !ok {
+		return
+	}
+	return copySet(&s.sets[idx]), true
+}
+
+func (s *Server) subscribe() chan string {
+	events := make(chan string, 64)
+	s.subscribersMu.Lock()
+	s.subscribers[events] = struct{}{}
+	s.subscribersMu.Unlock()
+	return events
+}
+
+func (s *Server) unsubscribe(events chan string) {
+	s.subscribersMu.Lock()
+	delete(s.subscribers, events)
+	s.subscribersMu.Unlock()
+}
+
+// broadcast notify subscribers without blocking, slow subscribers drop events
+// and will catch up with the latest chart options on the next event
+func (s *Server) broadcast(pkg string) {
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	for events := range s.subscribers {
+		select {
+		case events <- pkg:
+		default:
+		}
+	}
+}
+
+// copySet copy a set, Benchmark lists are copied so that they can be sorted while rendering
+func copySet(set *bench.Set) bench.Set {
+	copied := *set
+	copied.Targets = make(map[string]bench.BenchmarkList, len(set.Targets))
+	for target, benchmarks := range set.Targets {
+		copied.Targets[target] = append(bench.BenchmarkList{}, benchmarks...)
+	}
+	return copied
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>benchvisual</title></head>
+<body>
+<h2>Benchmark packages</h2>
+<ul id="packages">
+{{- range . }}
+    <li><a href="/page?pkg={{ . | urlquery }}">{{ . }}</a></li>
+{{- end }}
+</ul>
+<script type="text/javascript">
+    "use strict";
+    // reload the index when a new package shows up
+    const known = new Set({{ . }});
+    new EventSource("/events").onmessage = (event) => {
+        if (!known.has(event.data)) {
+            location.reload();
+        }
+    };
+</script>
+</body>
+</html>
+`))
+
+var liveScriptTemplate = template.Must(template.New("live").Parse(`<script type="text/javascript">
+    "use strict";
+    (() => {
+        const pkg = {{ . }};
+        let pending = false;
+        const refresh = () => {
+            pending = false;
+            fetch("/options?pkg=" + encodeURIComponent(pkg)).then((resp) => resp.json()).then((options) => {
+                for (const [chartID, option] of Object.entries(options)) {
+                    const element = document.getElementById(chartID);
+                    if (!element) {
+                        // a new chart shows up(e.g. a new value of the split dimension)
+                        location.reload();
+                        return;
+                    }
+                    const chart = echarts.getInstanceByDom(element);
+                    if (chart) {
+                        chart.setOption(option, true);
+                    }
+                }
+            });
+        };
+        new EventSource("/events").onmessage = (event) => {
+            // throttle updates, Benchmark lines may arrive in bursts
+            if (event.data === pkg && !pending) {
+                pending = true;
+                setTimeout(refresh, 500);
+            }
+        };
+    })();
+</script>
+`))
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/visual"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestServer(t *testing.T) {
+	convey.Convey("Given a Server fed with a Benchmark", t, func() {
+		srv := NewServer(visual.DefaultLayout)
+		httpServer := httptest.NewServer(srv.Handler())
+		defer httpServer.Close()
+
+		set := &bench.Set{Goos: "linux", Pkg: "demo/pkg"}
+		srv.AddBenchmark(set, &bench.Benchmark{Name: "BenchmarkFib/10", Target: "Fib", Scenario: "10", NsPerOp: 358})
+
+		convey.Convey("Index lists its package", func() {
+			body := get(httpServer.URL + "/")
+			convey.So(body, convey.ShouldContainSubstring, `href="/page?pkg=demo%2Fpkg"`)
+		})
+		convey.Convey("Page of the package has live update script", func() {
+			body := get(httpServer.URL + "/page?pkg=" + url.QueryEscape("demo/pkg"))
+			convey.So(body, convey.ShouldContainSubstring, `id="ns_per_op"`)
+			convey.So(body, convey.ShouldContainSubstring, `new EventSource("/events")`)
+		})
+		convey.Convey("Options of the package are keyed by chart id", func() {
+			body := get(httpServer.URL + "/options?pkg=" + url.QueryEscape("demo/pkg"))
+			convey.So(body, convey.ShouldContainSubstring, `"ns_per_op":`)
+			convey.So(body, convey.ShouldContainSubstring, `"value":358`)
+		})
+		convey.Convey("Unknown package is not found", func() {
+			resp, err := http.Get(httpServer.URL + "/page?pkg=unknown")
+			convey.So(err, convey.ShouldBeNil)
+			resp.Body.Close()
+			convey.So(resp.StatusCode, convey.ShouldEqual, http.StatusNotFound)
+		})
+		convey.Convey("Events are sent when Benchmark arrives", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/events", nil)
+			resp, err := http.DefaultClient.Do(req)
+			convey.So(err, convey.ShouldBeNil)
+			defer resp.Body.Close()
+
+			// wait for subscription, until the deadline of the test
+			subscribed := false
+			for !subscribed && ctx.Err() == nil {
+				srv.subscribersMu.Lock()
+				subscribed = len(srv.subscribers) > 0
+				srv.subscribersMu.Unlock()
+				if !subscribed {
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+			convey.So(subscribed, convey.ShouldBeTrue)
+			srv.AddBenchmark(set, &bench.Benchmark{Name: "BenchmarkFib/100", Target: "Fib", Scenario: "100", NsPerOp: 3580})
+			line, err := bufio.NewReader(resp.Body).ReadString('\n')
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(line, convey.ShouldEqual, "data: demo/pkg\n")
+			convey.So(srv.Sets()[0].Targets["Fib"], convey.ShouldHaveLength, 2)
+		})
+	})
+}
+
+func get(url string) string {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err.Error()
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return strings.TrimSpace(string(body))
+}
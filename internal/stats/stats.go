@@ -0,0 +1,347 @@
+// Package stats provide statistics used to tell real changes of Benchmark from noise
+//
+//	@update 2026-10-19 19:05:12
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Mean arithmetic mean of values, 0 for no value
+//
+//	@param values []float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// Variance unbiased sample variance of values, 0 for less than 2 values
+//
+//	@param values []float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func Variance(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := Mean(values)
+	var sum float64
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return sum / float64(len(values)-1)
+}
+
+// StdDev sample standard deviation of values
+//
+//	@param values []float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func StdDev(values []float64) float64 {
+	return math.Sqrt(Variance(values))
+}
+
+// Quantile q-quantile of values by linear interpolation between closest ranks(like R type 7), 0 for no value
+//
+//	@param values []float64
+//	@param q float64 in [0, 1]
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 20:21:36
+func Quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// Median median of values, 0 for no value
+//
+//	@param values []float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 20:21:36
+func Median(values []float64) float64 {
+	return Quantile(values, 0.5)
+}
+
+// MAD median absolute deviation of values
+//
+//	@param values []float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 20:21:36
+func MAD(values []float64) float64 {
+	median := Median(values)
+	deviations := make([]float64, len(values))
+	for idx, value := range values {
+		deviations[idx] = math.Abs(value - median)
+	}
+	return Median(deviations)
+}
+
+// OutliersIQR outliers by Tukey's fences, values out of [Q1 - k*IQR, Q3 + k*IQR] are outliers
+//
+//	@param values []float64
+//	@param k float64 usually 1.5
+//	@return outliers []bool whether every value is an outlier
+//	@author kevineluo
+//	@update 2026-10-19 20:21:36
+func OutliersIQR(values []float64, k float64) (outliers []bool) {
+	q1, q3 := Quantile(values, 0.25), Quantile(values, 0.75)
+	lower, upper := q1-k*(q3-q1), q3+k*(q3-q1)
+	outliers = make([]bool, len(values))
+	for idx, value := range values {
+		outliers[idx] = value < lower || value > upper
+	}
+	return
+}
+
+// OutliersMAD outliers by modified z-score(Iglewicz and Hoaglin), 0.6745*|x - median|/MAD > threshold are outliers,
+// when MAD is 0, every value other than the median is an outlier
+//
+//	@param values []float64
+//	@param threshold float64 usually 3.5
+//	@return outliers []bool whether every value is an outlier
+//	@author kevineluo
+//	@update 2026-10-19 20:21:36
+func OutliersMAD(values []float64, threshold float64) (outliers []bool) {
+	median, mad := Median(values), MAD(values)
+	outliers = make([]bool, len(values))
+	for idx, value := range values {
+		if mad == 0 {
+			outliers[idx] = value != median
+		} else {
+			outliers[idx] = 0.6745*math.Abs(value-median)/mad > threshold
+		}
+	}
+	return
+}
+
+// TTest result of a two-sample t-test
+type TTest struct {
+	T  float64 // t statistic, positive when the mean of b is greater
+	DF float64 // degrees of freedom
+	P  float64 // two-sided p-value
+}
+
+// WelchTTest Welch's t-test of two samples which may have unequal variances,
+// when both samples have no variance, P is 0 for different means and 1 for the same mean
+//
+//	@param a []float64
+//	@param b []float64
+//	@return result TTest
+//	@return ok bool false when a sample has less than 2 values
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func WelchTTest(a, b []float64) (result TTest, ok bool) {
+	if len(a) < 2 || len(b) < 2 {
+		return result, false
+	}
+	na, nb := float64(len(a)), float64(len(b))
+	sa, sb := Variance(a)/na, Variance(b)/nb
+	diff := Mean(b) - Mean(a)
+	if sa+sb == 0 {
+		result = TTest{DF: na + nb - 2, P: 1}
+		if diff != 0 {
+			result.T, result.P = math.Copysign(math.Inf(1), diff), 0
+		}
+		return result, true
+	}
+	result.T = diff / math.Sqrt(sa+sb)
+	result.DF = (sa + sb) * (sa + sb) / (sa*sa/(na-1) + sb*sb/(nb-1))
+	result.P = StudentTTwoSided(result.T, result.DF)
+	return result, true
+}
+
+// Comparison comparison of two sample sets, e.g. runs of a Benchmark before and after a change
+type Comparison struct {
+	Before float64 // mean of the samples before
+	After  float64 // mean of the samples after
+	Change float64 // relative change of the mean, (After-Before)/Before
+	P      float64 // two-sided p-value of Welch's t-test, 1 when the test can not be done
+}
+
+// Compare compare two sample sets by Welch's t-test
+//
+//	@param before []float64
+//	@param after []float64
+//	@return comparison Comparison
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func Compare(before, after []float64) (comparison Comparison) {
+	comparison = Comparison{Before: Mean(before), After: Mean(after), P: 1}
+	comparison.Change = RelativeChange(comparison.Before, comparison.After)
+	if result, ok := WelchTTest(before, after); ok {
+		comparison.P = result.P
+	}
+	return
+}
+
+// Significant whether the change is significant at the confidence(e.g. 0.99) and at least minChange(e.g. 0.02 for 2%) in size
+//
+//	@receiver comparison Comparison
+//	@param confidence float64
+//	@param minChange float64
+//	@return bool
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func (comparison Comparison) Significant(confidence, minChange float64) bool {
+	return comparison.P <= 1-confidence && math.Abs(comparison.Change) >= minChange
+}
+
+// RelativeChange relative change from before to after, infinite when before is 0 and after is not
+//
+//	@param before float64
+//	@param after float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func RelativeChange(before, after float64) float64 {
+	if before == 0 {
+		if after == 0 {
+			return 0
+		}
+		return math.Copysign(math.Inf(1), after)
+	}
+	return (after - before) / math.Abs(before)
+}
+
+// StudentTTwoSided two-sided p-value of t in Student's t distribution with df degrees of freedom, P(|T| >= |t|)
+//
+//	@param t float64
+//	@param df float64
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func StudentTTwoSided(t, df float64) float64 {
+	if math.IsInf(t, 0) {
+		return 0
+	}
+	return RegIncBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// StudentTQuantile critical value t of Student's t distribution with df degrees of freedom, where P(|T| >= t) = p,
+// found by bisection of StudentTTwoSided
+//
+//	@param p float64 two-sided tail probability in (0, 1), e.g. 0.05 for a 95% confidence interval
+//	@param df float64
+//	@return t float64
+//	@author kevineluo
+//	@update 2026-10-19 21:03:27
+func StudentTQuantile(p, df float64) (t float64) {
+	low, high := 0.0, 1.0
+	for StudentTTwoSided(high, df) > p {
+		high *= 2
+	}
+	for i := 0; i < 100 && high-low > 1e-12*high; i++ {
+		if mid := (low + high) / 2; StudentTTwoSided(mid, df) > p {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// ConfidenceInterval confidence interval of the mean of samples by Student's t distribution,
+// ok is false with less than 2 samples
+//
+//	@param values []float64
+//	@param confidence float64 e.g. 0.95
+//	@return low float64
+//	@return high float64
+//	@return ok bool
+//	@author kevineluo
+//	@update 2026-10-19 21:03:27
+func ConfidenceInterval(values []float64, confidence float64) (low, high float64, ok bool) {
+	n := float64(len(values))
+	if n < 2 {
+		return 0, 0, false
+	}
+	mean := Mean(values)
+	margin := StudentTQuantile(1-confidence, n-1) * StdDev(values) / math.Sqrt(n)
+	return mean - margin, mean + margin, true
+}
+
+// RegIncBeta regularized incomplete beta function I_x(a, b)
+//
+//	@param x float64 in [0, 1]
+//	@param a float64 > 0
+//	@param b float64 > 0
+//	@return float64
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func RegIncBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+	// the continued fraction converges fast for x < (a+1)/(a+b+2), use the symmetry I_x(a, b) = 1 - I_(1-x)(b, a) otherwise
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction continued fraction of the incomplete beta function, evaluated by the modified Lentz's method
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		for _, coef := range [2]float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + coef*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + coef/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
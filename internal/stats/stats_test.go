@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestStats(t *testing.T) {
+	convey.Convey("Describe samples", t, func() {
+		values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+		convey.So(Mean(values), convey.ShouldEqual, 5)
+		convey.So(Variance(values), convey.ShouldAlmostEqual, 32.0/7, 1e-12)
+		convey.So(StdDev([]float64{1}), convey.ShouldEqual, 0)
+		convey.So(Mean(nil), convey.ShouldEqual, 0)
+	})
+
+	convey.Convey("Compute p-values of Student's t distribution", t, func() {
+		// I_x(1, 1) = x
+		convey.So(RegIncBeta(0.3, 1, 1), convey.ShouldAlmostEqual, 0.3, 1e-12)
+		convey.So(StudentTTwoSided(0, 5), convey.ShouldAlmostEqual, 1, 1e-12)
+		// critical values of t tables
+		convey.So(StudentTTwoSided(2.571, 5), convey.ShouldAlmostEqual, 0.05, 1e-4)
+		convey.So(StudentTTwoSided(-2.228, 10), convey.ShouldAlmostEqual, 0.05, 1e-4)
+		convey.So(StudentTTwoSided(3.169, 10), convey.ShouldAlmostEqual, 0.01, 1e-4)
+		convey.So(StudentTTwoSided(math.Inf(1), 3), convey.ShouldEqual, 0)
+		convey.So(StudentTQuantile(0.05, 5), convey.ShouldAlmostEqual, 2.5706, 1e-4)
+		convey.So(StudentTQuantile(0.01, 10), convey.ShouldAlmostEqual, 3.1693, 1e-4)
+	})
+
+	convey.Convey("Estimate confidence interval of the mean", t, func() {
+		// mean 5, standard deviation 2
+		low, high, ok := ConfidenceInterval([]float64{3, 5, 7}, 0.95)
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(low, convey.ShouldAlmostEqual, 5-4.3027*2/math.Sqrt(3), 1e-3)
+		convey.So(high, convey.ShouldAlmostEqual, 5+4.3027*2/math.Sqrt(3), 1e-3)
+		_, _, ok = ConfidenceInterval([]float64{3}, 0.95)
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	convey.Convey("Compare two samples by Welch's t-test", t, func() {
+		result, ok := WelchTTest([]float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4},
+			[]float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4})
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(result.T, convey.ShouldAlmostEqual, 2.4554, 1e-3)
+		convey.So(result.DF, convey.ShouldAlmostEqual, 24.99, 0.01)
+		convey.So(result.P, convey.ShouldAlmostEqual, 0.021, 1e-3)
+
+		convey.Convey("Samples without variance", func() {
+			result, _ := WelchTTest([]float64{1, 1}, []float64{2, 2})
+			convey.So(result.P, convey.ShouldEqual, 0)
+			result, _ = WelchTTest([]float64{1, 1}, []float64{1, 1})
+			convey.So(result.P, convey.ShouldEqual, 1)
+			_, ok := WelchTTest([]float64{1}, []float64{1, 2})
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+	})
+}
+
+func TestCompare(t *testing.T) {
+	convey.Convey("Compare sample sets of a Benchmark", t, func() {
+		comparison := Compare([]float64{100, 101, 99, 100}, []float64{120, 121, 119, 120})
+		convey.So(comparison.Before, convey.ShouldEqual, 100)
+		convey.So(comparison.After, convey.ShouldEqual, 120)
+		convey.So(comparison.Change, convey.ShouldAlmostEqual, 0.2, 1e-12)
+		convey.So(comparison.Significant(0.99, 0.02), convey.ShouldBeTrue)
+		convey.So(comparison.Significant(0.99, 0.5), convey.ShouldBeFalse)
+
+		convey.So(Compare([]float64{100, 110, 90}, []float64{101, 111, 91}).Significant(0.95, 0), convey.ShouldBeFalse)
+		convey.So(Compare([]float64{100}, []float64{200}).P, convey.ShouldEqual, 1)
+		convey.So(RelativeChange(0, 1), convey.ShouldEqual, math.Inf(1))
+	})
+}
+
+func TestOutliers(t *testing.T) {
+	convey.Convey("Describe samples robustly", t, func() {
+		values := []float64{7, 1, 3, 5}
+		convey.So(Median(values), convey.ShouldEqual, 4)
+		convey.So(Quantile(values, 0.25), convey.ShouldEqual, 2.5)
+		convey.So(Quantile(values, 1), convey.ShouldEqual, 7)
+		convey.So(MAD([]float64{1, 1, 2, 2, 4, 6, 9}), convey.ShouldEqual, 1)
+		convey.So(values, convey.ShouldResemble, []float64{7, 1, 3, 5})
+	})
+
+	convey.Convey("Detect outliers of samples disturbed by GC or the scheduler", t, func() {
+		samples := []float64{100, 101, 99, 102, 100, 98, 160, 101}
+		expected := []bool{false, false, false, false, false, false, true, false}
+		convey.So(OutliersIQR(samples, 1.5), convey.ShouldResemble, expected)
+		convey.So(OutliersMAD(samples, 3.5), convey.ShouldResemble, expected)
+		convey.So(OutliersMAD([]float64{1, 1, 1, 2}, 3.5), convey.ShouldResemble, []bool{false, false, false, true})
+	})
+}
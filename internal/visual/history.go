@@ -0,0 +1,159 @@
+package visual
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/history"
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// VisualizeHistory visualize trends of Benchmark over runs and save html to saveDir,
+// every package is exported to a history-<pkg>.html, with a line chart for every metric of every Benchmark,
+// change points are marked on the charts of their metrics
+//
+//	@param saveDir string
+//	@param trends []history.Trend
+//	@param changes []history.ChangePoint
+//	@return savedPaths []string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func VisualizeHistory(saveDir string, trends []history.Trend, changes []history.ChangePoint) (savedPaths []string, err error) {
+	return visualizeTrends(saveDir, "history", "Benchmark history of ", trends, changes)
+}
+
+// VisualizeBisect visualize Benchmark of every step of a bisect and save html to saveDir,
+// every package is exported to a bisect-<pkg>.html, with a line chart for every metric of every Benchmark, steps are in x axis
+//
+//	@param saveDir string
+//	@param trends []history.Trend points of trends are steps of the bisect
+//	@return savedPaths []string
+//	@return err error
+//	@author kevineluo
+//	@update 2026-10-19 19:32:40
+func VisualizeBisect(saveDir string, trends []history.Trend) (savedPaths []string, err error) {
+	return visualizeTrends(saveDir, "bisect", "Benchmark bisect of ", trends, nil)
+}
+
+// visualizeTrends render trends to <prefix>-<pkg>.html, one page per package
+func visualizeTrends(saveDir string, prefix string, title string, trends []history.Trend, changes []history.ChangePoint) (savedPaths []string, err error) {
+	changesOf := make(map[history.Key][]history.ChangePoint)
+	for _, change := range changes {
+		changesOf[change.Key] = append(changesOf[change.Key], change)
+	}
+	pages := make(map[string]*components.Page)
+	var pkgs []string
+	for idx := range trends {
+		trend := &trends[idx]
+		page, ok := pages[trend.Key.Pkg]
+		if !ok {
+			page = components.NewPage()
+			page.PageTitle = title + trend.Key.Pkg
+			pages[trend.Key.Pkg] = page
+			pkgs = append(pkgs, trend.Key.Pkg)
+		}
+		for _, chart := range NewTrendCharts(trend, changesOf[trend.Key]) {
+			page.AddCharts(chart)
+		}
+	}
+
+	for _, pkg := range pkgs {
+		f, err := os.Create(filepath.Join(saveDir, prefix+"-"+strings.ReplaceAll(pkg, "/", "-")+".html"))
+		if err != nil {
+			return nil, fmt.Errorf("[visualizeTrends] error when create result file: %w", err)
+		}
+		err = pages[pkg].Render(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("[visualizeTrends] error when render %s page: %w", prefix, err)
+		}
+		savedPaths = append(savedPaths, f.Name())
+	}
+	return
+}
+
+// NewTrendCharts build a line chart for every metric of a Benchmark trend, runs are in x axis labeled by time and commit,
+// every change point of the metric is marked by a vertical line at its first run, labeled by the relative change
+//
+//	@param trend *history.Trend
+//	@param changes []history.ChangePoint change points of the trend
+//	@return lineCharts []*charts.Line
+//	@author kevineluo
+//	@update 2026-10-19 19:05:12
+func NewTrendCharts(trend *history.Trend, changes []history.ChangePoint) (lineCharts []*charts.Line) {
+	xAxis := make([]string, len(trend.Points))
+	for idx, point := range trend.Points {
+		xAxis[idx] = runLabel(point)
+	}
+	title := trend.Key.Target
+	if trend.Key.Scenario != "" {
+		title += "/" + trend.Key.Scenario
+	}
+	for _, unit := range trend.Units() {
+		line := charts.NewLine()
+		line.SetGlobalOptions(
+			charts.WithTitleOpts(opts.Title{
+				Title:    title,
+				Subtitle: fmt.Sprintf("Package: %s, Metric: %s", trend.Key.Pkg, unit),
+				Left:     "10%",
+			}),
+			charts.WithTooltipOpts(opts.Tooltip{Show: true, Trigger: "axis"}),
+			charts.WithInitializationOpts(opts.Initialization{Width: "1200px", Height: "400px"}),
+			charts.WithXAxisOpts(opts.XAxis{Name: "Run"}),
+			charts.WithYAxisOpts(opts.YAxis{Name: unit, Scale: true}),
+			charts.WithDataZoomOpts(opts.DataZoom{Type: "inside", Start: 0, End: 100}),
+		)
+		data := make([]opts.LineData, len(trend.Points))
+		for idx, value := range trend.Values(unit) {
+			if math.IsNaN(value) {
+				// not reported in the run
+				data[idx] = opts.LineData{Name: xAxis[idx], Value: "-"}
+				continue
+			}
+			data[idx] = opts.LineData{Name: xAxis[idx], Value: value}
+		}
+		seriesOpts := []charts.SeriesOpts{charts.WithLineChartOpts(opts.LineChart{ShowSymbol: true})}
+		for _, change := range changes {
+			if change.Unit != unit {
+				continue
+			}
+			name := fmt.Sprintf("%+.1f%%", change.Change*100)
+			if change.Regression {
+				name = "regression " + name
+			}
+			seriesOpts = append(seriesOpts, charts.WithMarkLineNameXAxisItemOpts(opts.MarkLineNameXAxisItem{Name: name, XAxis: xAxis[change.Index]}))
+		}
+		if len(seriesOpts) > 1 {
+			seriesOpts = append(seriesOpts, charts.WithMarkLineStyleOpts(opts.MarkLineStyle{
+				Symbol: []string{"none", "none"},
+				Label:  &opts.Label{Show: true, Formatter: "{b}"},
+			}))
+		}
+		line.SetXAxis(xAxis).AddSeries(unit, data, seriesOpts...)
+		lineCharts = append(lineCharts, line)
+	}
+	return
+}
+
+// runLabel label of a run in x axis, its time and short commit
+func runLabel(point history.Point) string {
+	label := point.Timestamp.Local().Format("2006-01-02 15:04:05")
+	if point.Commit != "" {
+		// e.g. 0123456789abcdef-dirty -> 0123456-dirty
+		hash, suffix, _ := strings.Cut(point.Commit, "-")
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		label += "\n" + hash
+		if suffix != "" {
+			label += "-" + suffix
+		}
+	}
+	return label
+}
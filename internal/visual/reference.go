@@ -0,0 +1,139 @@
+package visual
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/Kevinello/benchvisual/internal/stats"
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+)
+
+// NewRatioCharts build bar charts of every metric relative to the reference target(Layout.Reference) of a benchmark set,
+// every cell is divided by the cell of the reference target with the same dimensions(e.g. the same scenario),
+// or the reverse for speedup(Layout.Speedup, higher is better for every metric), the reference is drawn as a 1.0 line.
+// a cell whose reference is 0(e.g. allocs/op) is 1 when it is 0 as well, or marked as infinite over the reference line.
+// chart ids are suffixed by '_ratio'(e.g. ns_per_op_ratio), no chart without the reference target in the set
+//
+//	@param set *bench.Set
+//	@param layout Layout
+//	@return barCharts []*charts.Bar
+//	@author kevineluo
+//	@update 2026-10-19 21:26:48
+func NewRatioCharts(set *bench.Set, layout Layout) (barCharts []*charts.Bar) {
+	references := set.Targets[layout.Reference]
+	if layout.Reference == "" || len(references) == 0 {
+		return nil
+	}
+	groups, splits := splitBenchmarks(set, layout)
+	for _, metric := range metrics {
+		// means of the reference target by coordinates(series, x axis and split)
+		referenceValues := make(map[[3]string][]float64)
+		for idx := range references {
+			coordinates := coordinatesOf(&references[idx], layout)
+			referenceValues[coordinates] = append(referenceValues[coordinates], metric.value(&references[idx]))
+		}
+
+		for _, split := range splits {
+			chartID, title := chartOf(metric, layout, split)
+			if layout.Speedup {
+				title = fmt.Sprintf("%s, speedup over %s", title, layout.Reference)
+			} else {
+				title = fmt.Sprintf("%s, ratio to %s", title, layout.Reference)
+			}
+			bar := charts.NewBar()
+			bar.SetGlobalOptions(
+				append(options,
+					charts.WithTitleOpts(opts.Title{
+						Title:    title,
+						Subtitle: fmt.Sprintf("Package: %s\nOS: %s, ARCH: %s, CPU: %s", set.Pkg, set.Goos, set.Goarch, set.CPU),
+						Top:      "0%",
+						Left:     "10%",
+					}),
+					charts.WithXAxisOpts(opts.XAxis{
+						Name:      "Benchmark\n" + capitalize(layout.XAxis),
+						SplitLine: &opts.SplitLine{Show: true},
+					}),
+					// ratios in tooltips, e.g. 1.25x
+					charts.WithTooltipOpts(opts.Tooltip{Show: true, Formatter: "{a}<br/>{b}: {c}x"}),
+				)...,
+			).SetSeriesOptions(
+				charts.WithBarChartOpts(opts.BarChart{BarGap: "0%"}),
+			)
+			bar.ChartID = chartID + "_ratio"
+
+			cells, seriesNames, xAxis := cellsOf(groups[split], layout, metric)
+			bar.SetXAxis(xAxis)
+			for idx, series := range seriesNames {
+				data := make([]opts.BarData, 0, len(xAxis))
+				// cells divided by a zero reference
+				var infinities []opts.MarkPointNameCoordItem
+				for _, x := range xAxis {
+					c, ok := cells[[2]string{series, x}]
+					reference := referenceValues[referenceOf([3]string{series, x, split}, layout)]
+					if !ok || len(reference) == 0 {
+						data = append(data, opts.BarData{Name: x, Value: "-"})
+						continue
+					}
+					ratio, finite := relativeTo(c.mean(), stats.Mean(reference), metric.unit, layout.Speedup)
+					if !finite {
+						data = append(data, opts.BarData{Name: x, Value: "-"})
+						infinities = append(infinities, opts.MarkPointNameCoordItem{
+							Name: fmt.Sprintf("%s: %.6g, %s: %.6g", series, c.mean(), layout.Reference, stats.Mean(reference)), Coordinate: []interface{}{x, 1},
+							Value: "∞", Symbol: "pin", SymbolSize: 30,
+						})
+						continue
+					}
+					data = append(data, opts.BarData{Name: x, Value: math.Round(ratio*1e4) / 1e4})
+				}
+				var seriesOpts []charts.SeriesOpts
+				if idx == 0 {
+					seriesOpts = append(seriesOpts,
+						charts.WithMarkLineNameYAxisItemOpts(opts.MarkLineNameYAxisItem{Name: layout.Reference, YAxis: 1}),
+						charts.WithMarkLineStyleOpts(opts.MarkLineStyle{Symbol: []string{"none", "none"}, Label: &opts.Label{Show: true, Formatter: "{b}"}}),
+					)
+				}
+				if len(infinities) > 0 {
+					seriesOpts = append(seriesOpts, charts.WithMarkPointNameCoordItemOpts(infinities...))
+				}
+				bar.AddSeries(series, data, seriesOpts...)
+			}
+			barCharts = append(barCharts, bar)
+		}
+	}
+	return
+}
+
+// coordinatesOf coordinates(series, x axis and split) of a Benchmark in charts of the layout
+func coordinatesOf(benchmark *bench.Benchmark, layout Layout) (coordinates [3]string) {
+	for idx, dimension := range []string{layout.Series, layout.XAxis, layout.Split} {
+		if dimension != "" {
+			coordinates[idx] = benchmark.Dimension(dimension)
+		}
+	}
+	return
+}
+
+// referenceOf coordinates of the reference of a cell, where the target dimension is replaced by the reference target
+func referenceOf(coordinates [3]string, layout Layout) [3]string {
+	for idx, dimension := range []string{layout.Series, layout.XAxis, layout.Split} {
+		if dimension == "target" {
+			coordinates[idx] = layout.Reference
+		}
+	}
+	return coordinates
+}
+
+// relativeTo value relative to reference, the ratio value / reference, or the speedup which is higher for better values,
+// finite is false when divided by 0, while 0 / 0 is 1
+func relativeTo(value, reference float64, unit string, speedup bool) (relative float64, finite bool) {
+	numerator, denominator := value, reference
+	if speedup && !bench.HigherIsBetter(unit) {
+		numerator, denominator = reference, value
+	}
+	if denominator == 0 {
+		return 1, numerator == 0
+	}
+	return numerator / denominator, true
+}
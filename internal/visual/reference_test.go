@@ -0,0 +1,61 @@
+package visual
+
+import (
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewRatioCharts(t *testing.T) {
+	convey.Convey("Given Benchmark of pools and plain goroutines", t, func() {
+		set := &bench.Set{Pkg: "demo", Targets: map[string]bench.BenchmarkList{
+			"Goroutines": {
+				{Target: "Goroutines", Scenario: "1K", NsPerOp: 200},
+				{Target: "Goroutines", Scenario: "1M", NsPerOp: 400, Mem: bench.Mem{AllocsPerOp: 2}},
+			},
+			"Ants": {
+				{Target: "Ants", Scenario: "1K", NsPerOp: 100, Mem: bench.Mem{AllocsPerOp: 1}},
+				{Target: "Ants", Scenario: "1M", NsPerOp: 500, Mem: bench.Mem{AllocsPerOp: 1}},
+			},
+		}}
+
+		convey.Convey("No relative chart without reference target", func() {
+			convey.So(NewRatioCharts(set, DefaultLayout), convey.ShouldBeEmpty)
+			convey.So(NewRatioCharts(set, Layout{Series: "target", XAxis: "scenario", Reference: "Pond"}), convey.ShouldBeEmpty)
+			convey.So(NewCharts(set, DefaultLayout), convey.ShouldHaveLength, len(metrics))
+		})
+
+		convey.Convey("Metrics are divided by the reference in the same scenario", func() {
+			layout := Layout{Series: "target", XAxis: "scenario", Reference: "Goroutines"}
+			convey.So(NewCharts(set, layout), convey.ShouldHaveLength, 2*len(metrics))
+			ratioCharts := NewRatioCharts(set, layout)
+			convey.So(ratioCharts, convey.ShouldHaveLength, len(metrics))
+			chart := ratioCharts[0]
+			convey.So(chart.ChartID, convey.ShouldEqual, "ns_per_op_ratio")
+			convey.So(chart.MultiSeries[0].Name, convey.ShouldEqual, "Ants")
+			convey.So(chart.MultiSeries[0].Data, convey.ShouldResemble, []opts.BarData{{Name: "1K", Value: 0.5}, {Name: "1M", Value: 1.25}})
+			convey.So(chart.MultiSeries[1].Data, convey.ShouldResemble, []opts.BarData{{Name: "1K", Value: float64(1)}, {Name: "1M", Value: float64(1)}})
+			// the 1.0 reference line
+			convey.So(chart.MultiSeries[0].MarkLines.Data, convey.ShouldResemble, []interface{}{opts.MarkLineNameYAxisItem{Name: "Goroutines", YAxis: 1}})
+
+			convey.Convey("Zero reference of allocs/op is marked as infinite", func() {
+				allocs := ratioCharts[2]
+				convey.So(allocs.MultiSeries[0].Data, convey.ShouldResemble, []opts.BarData{{Name: "1K", Value: "-"}, {Name: "1M", Value: 0.5}})
+				infinities := allocs.MultiSeries[0].MarkPoints.Data
+				convey.So(infinities, convey.ShouldHaveLength, 1)
+				convey.So(infinities[0].(opts.MarkPointNameCoordItem).Name, convey.ShouldEqual, "Ants: 1, Goroutines: 0")
+			})
+		})
+
+		convey.Convey("Speedup is higher for better values", func() {
+			chart := NewRatioCharts(set, Layout{Series: "scenario", XAxis: "target", Reference: "Goroutines", Speedup: true})[0]
+			chart.Validate()
+			convey.So(chart.XAxisList[0].Data, convey.ShouldResemble, []string{"Ants", "Goroutines"})
+			convey.So(chart.MultiSeries[0].Name, convey.ShouldEqual, "1K")
+			convey.So(chart.MultiSeries[0].Data, convey.ShouldResemble, []opts.BarData{{Name: "Ants", Value: float64(2)}, {Name: "Goroutines", Value: float64(1)}})
+			convey.So(chart.MultiSeries[1].Data, convey.ShouldResemble, []opts.BarData{{Name: "Ants", Value: 0.8}, {Name: "Goroutines", Value: float64(1)}})
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package visual
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+)
+
+// NoiseSummary html section listing statistically weak Benchmark of a set with their noise warnings,
+// empty when there is no warning
+//
+//	@param set *bench.Set
+//	@return section string
+//	@author kevineluo
+//	@update 2026-10-19 20:44:09
+func NoiseSummary(set *bench.Set) (section string) {
+	// runs of a Benchmark share the same warnings
+	warningsOf := make(map[string][]bench.NoiseWarning)
+	for target := range set.Targets {
+		for _, benchmark := range set.Targets[target] {
+			if len(benchmark.Warnings) > 0 {
+				name := benchmark.Name
+				if len(benchmark.Labels) > 0 {
+					name += " " + fmt.Sprint(benchmark.Labels)
+				}
+				warningsOf[name] = benchmark.Warnings
+			}
+		}
+	}
+	if len(warningsOf) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(warningsOf))
+	for name := range warningsOf {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows [][]string
+	for _, name := range names {
+		for _, warning := range warningsOf[name] {
+			rows = append(rows, []string{name, warning.Kind, warning.Message})
+		}
+	}
+	builder := new(strings.Builder)
+	fmt.Fprintf(builder, `<div class="noise-summary" style="%s">
+<h3 style="color: %s;">Reliability warnings</h3>
+<p>results of these Benchmark are statistically weak, don't draw conclusions from them before re-running with more iterations(-benchtime) or runs(-count).</p>
+`, sectionStyle, warningColor)
+	writeTable(builder, []string{"Benchmark", "Warning", "Detail"}, rows)
+	builder.WriteString("</div>\n")
+	return builder.String()
+}
+
+// RankingSummary html section of the ranking(see bench.Rank) of a set, the overall ranking first,
+// then targets ranked in every scenario and metric, empty when the set is not ranked
+//
+//	@param set *bench.Set
+//	@return section string
+//	@author kevineluo
+//	@update 2026-10-19 21:48:15
+func RankingSummary(set *bench.Set) (section string) {
+	if set.Ranking == nil {
+		return ""
+	}
+	builder := new(strings.Builder)
+	fmt.Fprintf(builder, "<div class=\"ranking-summary\" style=\"%s\">\n", sectionStyle)
+
+	if len(set.Ranking.Overall) > 0 {
+		weights := make([]string, 0, len(set.Ranking.Weights))
+		for unit, weight := range set.Ranking.Weights {
+			if weight > 0 {
+				weights = append(weights, fmt.Sprintf("%s=%g", unit, weight))
+			}
+		}
+		sort.Strings(weights)
+		fmt.Fprintf(builder, "<h3>Overall ranking</h3>\n<p>score is the weighted geometric mean of ratios to the best target in every scenario all targets are in, 1 means the best everywhere, weights: %s</p>\n",
+			html.EscapeString(strings.Join(weights, ", ")))
+		rows := make([][]string, 0, len(set.Ranking.Overall))
+		for idx, rank := range set.Ranking.Overall {
+			rows = append(rows, []string{fmt.Sprint(idx + 1), rank.Target, fmt.Sprintf("%.4f", rank.Score), formatGap(&rank.Gap), fmt.Sprint(rank.Rankings)})
+		}
+		writeTable(builder, []string{"Rank", "Target", "Score", "Gap", "Rankings"}, rows)
+	}
+
+	builder.WriteString("<h3>Ranking by scenario</h3>\n")
+	var rows [][]string
+	for _, scenarioRanking := range set.Ranking.Scenarios {
+		scenario := scenarioRanking.Scenario
+		if len(scenarioRanking.Labels) > 0 {
+			scenario += " " + fmt.Sprint(scenarioRanking.Labels)
+		}
+		for idx, rank := range scenarioRanking.Ranks {
+			rows = append(rows, []string{scenario, scenarioRanking.Unit, fmt.Sprint(idx + 1), rank.Target, fmt.Sprintf("%.6g", rank.Value), formatGap(rank.Gap)})
+		}
+	}
+	writeTable(builder, []string{"Scenario", "Metric", "Rank", "Target", "Value", "Gap"}, rows)
+	builder.WriteString("</div>\n")
+	return builder.String()
+}
+
+// sectionStyle style of html sections under the charts
+const sectionStyle = "margin: 20px 10%; font-family: sans-serif;"
+
+// writeTable write an html table, cells are escaped
+func writeTable(builder *strings.Builder, headers []string, rows [][]string) {
+	const cellStyle = "text-align: left; padding: 4px 12px;"
+	builder.WriteString("<table style=\"border-collapse: collapse;\">\n<tr>")
+	for _, header := range headers {
+		fmt.Fprintf(builder, "<th style=\"%s\">%s</th>", cellStyle, html.EscapeString(header))
+	}
+	builder.WriteString("</tr>\n")
+	for _, row := range rows {
+		builder.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(builder, "<td style=\"%s\">%s</td>", cellStyle, html.EscapeString(cell))
+		}
+		builder.WriteString("</tr>\n")
+	}
+	builder.WriteString("</table>\n")
+}
+
+// formatGap relative gap to the best, e.g. +25.00%, best for no gap and ∞ for no relative gap to a zero best
+func formatGap(gap *float64) string {
+	switch {
+	case gap == nil:
+		return "∞"
+	case *gap == 0:
+		return "best"
+	}
+	return fmt.Sprintf("+%.2f%%", *gap*100)
+}
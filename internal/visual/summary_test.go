@@ -0,0 +1,29 @@
+package visual
+
+import (
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestRankingSummary(t *testing.T) {
+	convey.Convey("Given a ranked Benchmark set", t, func() {
+		set := &bench.Set{Pkg: "demo", Targets: map[string]bench.BenchmarkList{
+			"Goroutines": {{Target: "Goroutines", Scenario: "1K", NsPerOp: 200, Mem: bench.Mem{Benchmem: true}}},
+			"Ants":       {{Target: "Ants", Scenario: "1K", NsPerOp: 100, Mem: bench.Mem{AllocsPerOp: 1}}},
+		}}
+		convey.So(RankingSummary(set), convey.ShouldBeEmpty)
+		sets := []bench.Set{*set}
+		bench.Rank(sets, bench.DefaultRankWeights)
+
+		convey.Convey("Rankings are listed as tables", func() {
+			summary := RankingSummary(&sets[0])
+			convey.So(summary, convey.ShouldContainSubstring, "Overall ranking")
+			convey.So(summary, convey.ShouldContainSubstring, "weights: B/op=1, allocs/op=1, ns/op=1")
+			convey.So(summary, convey.ShouldContainSubstring, "<td style=\"text-align: left; padding: 4px 12px;\">+100.00%</td>")
+			// allocs/op against zero
+			convey.So(summary, convey.ShouldContainSubstring, "<td style=\"text-align: left; padding: 4px 12px;\">∞</td>")
+		})
+	})
+}
@@ -4,14 +4,17 @@
 package visual
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Kevinello/benchvisual/internal/bench"
 	"github.com/Kevinello/benchvisual/internal/collections"
+	"github.com/Kevinello/benchvisual/internal/stats"
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/components"
 	"github.com/go-echarts/go-echarts/v2/opts"
@@ -27,12 +30,6 @@ var options = []charts.GlobalOpts{
 		Right:  "0%",
 		// Padding: 5,
 	}),
-	charts.WithXAxisOpts(opts.XAxis{
-		Name: "Benchmark\nTarget",
-		SplitLine: &opts.SplitLine{
-			Show: true,
-		},
-	}),
 	charts.WithInitializationOpts(opts.Initialization{
 		Width:  "1200px",
 		Height: "600px",
@@ -65,11 +62,57 @@ var options = []charts.GlobalOpts{
 	),
 }
 
+// Layout dimensions of Benchmark which drive the charts, a dimension is 'target', 'scenario' or a label of Benchmark,
+// and how samples(runs of a Benchmark, e.g. by -count) in a chart cell are drawn
+//
+//	@author kevineluo
+//	@update 2026-10-19 21:03:27
+type Layout struct {
+	Series    string // dimension as series(legend) of a chart
+	XAxis     string // dimension as x axis of a chart
+	Split     string // dimension to split charts by, one chart per value for every metric, empty for no split
+	ErrorBars string // error bars over bars with 2+ samples, one of ErrorBarModes, empty for none
+	BoxPlot   bool   // add a box plot chart of the sample distribution for every bar chart
+	Reference string // target to add charts relative to, see NewRatioCharts, empty for none
+	Speedup   bool   // charts relative to Reference show speedup instead of ratio
+}
+
+// modes of error bars
+const (
+	ErrorBarsNone   = "none"
+	ErrorBarsMinMax = "minmax" // from the minimum to the maximum sample
+	ErrorBarsCI     = "ci"     // 95% confidence interval of the mean
+)
+
+// ErrorBarModes supported modes of error bars
+var ErrorBarModes = []string{ErrorBarsNone, ErrorBarsMinMax, ErrorBarsCI}
+
+// errorBarConfidence confidence of ErrorBarsCI
+const errorBarConfidence = 0.95
+
+// DefaultLayout compare targets(series) in each scenario(x axis)
+var DefaultLayout = Layout{Series: "target", XAxis: "scenario"}
+
+// metric a metric visualized as a bar chart
+type metric struct {
+	chartID string
+	title   string
+	unit    string
+	value   func(benchmark *bench.Benchmark) float64
+}
+
+var metrics = []metric{
+	{"ns_per_op", "Time cost per option(ns)", "ns/op", func(benchmark *bench.Benchmark) float64 { return benchmark.NsPerOp }},
+	{"bytes_per_op", "Alloced bytes per option", "B/op", func(benchmark *bench.Benchmark) float64 { return benchmark.Mem.BytesPerOp }},
+	{"allocs_per_op", "Alloc times per option", "allocs/op", func(benchmark *bench.Benchmark) float64 { return benchmark.Mem.AllocsPerOp }},
+	{"mb_per_sec", "Alloc mem size per sec(MB)", "MB/s", func(benchmark *bench.Benchmark) float64 { return benchmark.Mem.MBPerSec }},
+}
+
 // Visualize visualize benchmark sets and save html to target path
 // every set will be visualize as 3+ bar charts for 3+ metrics(include custom metrics),
 // and be exported to html files in the given saveDir
 //
-//	@Concept alignment:
+//	@Concept alignment(in DefaultLayout):
 //	bench.Set(package) -> page(html)
 //	metrics(ns/op...)  -> series of metrics value
 //	targets            -> series name(x axis)
@@ -77,80 +120,351 @@ var options = []charts.GlobalOpts{
 //
 //	@param saveDir string
 //	@param sets []bench.Set
+//	@param layout Layout
 //	@return savedPaths []string
 //	@return err error
 //	@author kevineluo
-//	@update 2023-03-07 02:14:40
-func Visualize(saveDir string, sets []bench.Set) (savedPaths []string, err error) {
+//	@update 2026-10-19 17:41:50
+func Visualize(saveDir string, sets []bench.Set, layout Layout) (savedPaths []string, err error) {
 	for _, set := range sets {
-		// get all scenarios
-		scenarios := set.GetScenarios()
-		// ns/op
-		timePerOPChart := charts.NewBar()
-		setupBarChart(timePerOPChart, &set, "Time cost per option(ns)", scenarios)
-		// bytes/op
-		memPerOPChart := charts.NewBar()
-		setupBarChart(memPerOPChart, &set, "Alloced bytes per option", scenarios)
-		// allocs/op
-		allocsPerOPChart := charts.NewBar()
-		setupBarChart(allocsPerOPChart, &set, "Alloc times per option", scenarios)
-		// MB/s
-		memPerSecChart := charts.NewBar()
-		setupBarChart(memPerSecChart, &set, "Alloc mem size per sec(MB)", scenarios)
-		// TODO: custom metrics
-		// customMetricsCharts := make(map[string]*charts.Bar, 0)
-
-		// generate series
-		for target, benchmarks := range set.Targets {
-			// sort the benchmarks by its scenario first for generating series
-			sort.Sort(benchmarks)
-
-			timePerOPSeries := collections.Map(benchmarks, func(benchmark bench.Benchmark) (barData opts.BarData) {
-				return opts.BarData{Name: benchmark.Name, Value: benchmark.NsPerOp}
-			})
-			timePerOPChart.AddSeries(target, timePerOPSeries)
-			memPerOPSeries := collections.Map(benchmarks, func(benchmark bench.Benchmark) (barData opts.BarData) {
-				return opts.BarData{Name: benchmark.Name, Value: benchmark.Mem.BytesPerOp}
-			})
-			memPerOPChart.AddSeries(target, memPerOPSeries)
-			allocsPerOPSeries := collections.Map(benchmarks, func(benchmark bench.Benchmark) (barData opts.BarData) {
-				return opts.BarData{Name: benchmark.Name, Value: benchmark.Mem.AllocsPerOp}
-			})
-			allocsPerOPChart.AddSeries(target, allocsPerOPSeries)
-			memPerSecSeries := collections.Map(benchmarks, func(benchmark bench.Benchmark) (barData opts.BarData) {
-				return opts.BarData{Name: benchmark.Name, Value: benchmark.Mem.MBPerSec}
-			})
-			memPerSecChart.AddSeries(target, memPerSecSeries)
+		page := NewPage(&set, layout)
+		buffer := new(bytes.Buffer)
+		if err = page.Render(buffer); err != nil {
+			return nil, fmt.Errorf("[Visualize] error when render page of %s: %w", set.Pkg, err)
 		}
-
-		page := components.NewPage()
-		page.AddCharts(timePerOPChart, memPerOPChart, allocsPerOPChart, memPerSecChart)
-		f, err := os.Create(filepath.Join(saveDir, strings.ReplaceAll(set.Pkg, "/", "-")+".html"))
-		if err != nil {
+		// rankings and statistically weak Benchmark are listed under the charts
+		content := strings.Replace(buffer.String(), "</body>", RankingSummary(&set)+NoiseSummary(&set)+"</body>", 1)
+		savedPath := filepath.Join(saveDir, strings.ReplaceAll(set.Pkg, "/", "-")+".html")
+		if err = os.WriteFile(savedPath, []byte(content), os.ModePerm); err != nil {
 			return nil, fmt.Errorf("[Visualize] error when create result file: %w", err)
 		}
-		defer f.Close()
-		page.Render(f)
-		savedPaths = append(savedPaths, f.Name())
+		savedPaths = append(savedPaths, savedPath)
+	}
+	return
+}
+
+// NewPage build the page of a benchmark set, with bar charts for every metric
+//
+//	@param set *bench.Set
+//	@param layout Layout
+//	@return page *components.Page
+//	@author kevineluo
+//	@update 2026-10-19 17:41:50
+func NewPage(set *bench.Set, layout Layout) (page *components.Page) {
+	page = components.NewPage()
+	for _, chart := range NewCharts(set, layout) {
+		page.AddCharts(chart)
+	}
+	if layout.BoxPlot {
+		for _, chart := range NewBoxPlots(set, layout) {
+			page.AddCharts(chart)
+		}
+	}
+	return
+}
+
+// NewCharts build bar charts for every metric of a benchmark set, followed by charts relative to the reference target if any,
+// chart ids are fixed(e.g. ns_per_op, or ns_per_op-<split value>), so charts of the same set can be updated in place by their ids.
+// Benchmark falling into the same series and x axis value(e.g. runs of -count) are averaged
+//
+//	@param set *bench.Set
+//	@param layout Layout
+//	@return barCharts []*charts.Bar
+//	@author kevineluo
+//	@update 2026-10-19 21:26:48
+func NewCharts(set *bench.Set, layout Layout) (barCharts []*charts.Bar) {
+	groups, splits := splitBenchmarks(set, layout)
+	for _, metric := range metrics {
+		for _, split := range splits {
+			bar := charts.NewBar()
+			chartID, title := chartOf(metric, layout, split)
+			setupBarChart(bar, set, chartID, title, layout, groups[split], metric)
+			barCharts = append(barCharts, bar)
+		}
+	}
+	return append(barCharts, NewRatioCharts(set, layout)...)
+}
+
+// NewBoxPlots build box plot charts of the sample distribution(minimum, quartiles and maximum) of every metric of a benchmark set,
+// with the same series, x axis and split as NewCharts, chart ids are suffixed by '_box'(e.g. ns_per_op_box)
+//
+//	@param set *bench.Set
+//	@param layout Layout
+//	@return boxPlots []*charts.BoxPlot
+//	@author kevineluo
+//	@update 2026-10-19 21:03:27
+func NewBoxPlots(set *bench.Set, layout Layout) (boxPlots []*charts.BoxPlot) {
+	groups, splits := splitBenchmarks(set, layout)
+	for _, metric := range metrics {
+		for _, split := range splits {
+			chartID, title := chartOf(metric, layout, split)
+			boxPlot := charts.NewBoxPlot()
+			boxPlot.SetGlobalOptions(
+				append(options,
+					charts.WithTitleOpts(opts.Title{
+						Title:    title + " distribution",
+						Subtitle: fmt.Sprintf("Package: %s\nOS: %s, ARCH: %s, CPU: %s", set.Pkg, set.Goos, set.Goarch, set.CPU),
+						Top:      "0%",
+						Left:     "10%",
+					}),
+					charts.WithXAxisOpts(opts.XAxis{
+						Name:      "Benchmark\n" + capitalize(layout.XAxis),
+						SplitLine: &opts.SplitLine{Show: true},
+					}),
+				)...,
+			)
+			boxPlot.ChartID = chartID + "_box"
+
+			cells, seriesNames, xAxis := cellsOf(groups[split], layout, metric)
+			boxPlot.SetXAxis(xAxis)
+			for _, series := range seriesNames {
+				data := make([]opts.BoxPlotData, 0, len(xAxis))
+				for _, x := range xAxis {
+					if c, ok := cells[[2]string{series, x}]; ok {
+						data = append(data, opts.BoxPlotData{Name: x, Value: []float64{
+							stats.Quantile(c.values, 0), stats.Quantile(c.values, 0.25), stats.Median(c.values),
+							stats.Quantile(c.values, 0.75), stats.Quantile(c.values, 1),
+						}})
+					} else {
+						// no box without value
+						data = append(data, opts.BoxPlotData{Name: x})
+					}
+				}
+				boxPlot.AddSeries(series, data)
+			}
+			boxPlots = append(boxPlots, boxPlot)
+		}
+	}
+	return
+}
+
+// splitBenchmarks group Benchmark of a set by split value, with sorted split values, all Benchmark are in the "" split without split
+func splitBenchmarks(set *bench.Set, layout Layout) (groups map[string][]*bench.Benchmark, splits []string) {
+	groups = make(map[string][]*bench.Benchmark)
+	for target := range set.Targets {
+		benchmarks := set.Targets[target]
+		for idx := range benchmarks {
+			split := ""
+			if layout.Split != "" {
+				split = benchmarks[idx].Dimension(layout.Split)
+			}
+			groups[split] = append(groups[split], &benchmarks[idx])
+		}
+	}
+	splits = make([]string, 0, len(groups))
+	for split := range groups {
+		splits = append(splits, split)
+	}
+	return groups, SortValues(splits)
+}
+
+// chartOf chart id and title of a metric in a split
+func chartOf(metric metric, layout Layout, split string) (chartID string, title string) {
+	chartID, title = metric.chartID, metric.title
+	if layout.Split != "" {
+		chartID = chartID + "-" + chartIDReplacer.Replace(split)
+		title = fmt.Sprintf("%s, %s=%s", title, layout.Split, split)
 	}
 	return
 }
 
-func setupBarChart(bar *charts.Bar, set *bench.Set, title string, scenarios []string) {
+// cell samples of a metric of Benchmark falling into the same (series, x axis) cell, with kinds of noise warnings of them
+type cell struct {
+	values   []float64
+	warnings collections.Set[string]
+}
+
+// mean point estimate of the cell
+func (c *cell) mean() float64 {
+	return stats.Mean(c.values)
+}
+
+// errorBar range of the error bar of the cell, ok is false without error bar
+func (c *cell) errorBar(mode string) (low, high float64, ok bool) {
+	if len(c.values) < 2 {
+		return 0, 0, false
+	}
+	switch mode {
+	case ErrorBarsMinMax:
+		return stats.Quantile(c.values, 0), stats.Quantile(c.values, 1), true
+	case ErrorBarsCI:
+		return stats.ConfidenceInterval(c.values, errorBarConfidence)
+	}
+	return 0, 0, false
+}
+
+// cellsOf samples of a metric of Benchmark by (series, x axis) cell, with sorted series names and x axis values
+func cellsOf(benchmarks []*bench.Benchmark, layout Layout, metric metric) (cells map[[2]string]*cell, seriesNames []string, xAxis []string) {
+	cells = make(map[[2]string]*cell)
+	seriesSet, xSet := collections.NewSet[string](0), collections.NewSet[string](0)
+	for _, benchmark := range benchmarks {
+		key := [2]string{benchmark.Dimension(layout.Series), benchmark.Dimension(layout.XAxis)}
+		seriesSet.Add(key[0])
+		xSet.Add(key[1])
+		if cells[key] == nil {
+			cells[key] = &cell{warnings: collections.NewSet[string](0)}
+		}
+		cells[key].values = append(cells[key].values, metric.value(benchmark))
+		for _, warning := range benchmark.Warnings {
+			cells[key].warnings.Add(warning.Kind)
+		}
+	}
+	return cells, SortValues(seriesSet.ToSlice()), SortValues(xSet.ToSlice())
+}
+
+// warningColor color of noise warning badges and summary
+const warningColor = "#e6a23c"
+
+// chartIDReplacer make a dimension value usable in DOM id
+var chartIDReplacer = strings.NewReplacer(" ", "_", "/", "_", "=", "_", ".", "_", "#", "_", "\"", "_", "'", "_")
+
+func setupBarChart(bar *charts.Bar, set *bench.Set, chartID string, title string, layout Layout,
+	benchmarks []*bench.Benchmark, metric metric) {
+	subtitle := fmt.Sprintf("Package: %s\nOS: %s, ARCH: %s, CPU: %s", set.Pkg, set.Goos, set.Goarch, set.CPU)
+	if set.OutliersDropped > 0 {
+		subtitle += fmt.Sprintf("\nOutlier samples dropped: %d", set.OutliersDropped)
+	}
 	bar.SetGlobalOptions(
 		append(options,
 			charts.WithTitleOpts(opts.Title{
 				Title:    title,
-				Subtitle: fmt.Sprintf("Package: %s\nOS: %s, ARCH: %s, CPU: %s", set.Pkg, set.Goos, set.Goarch, set.CPU),
+				Subtitle: subtitle,
 				Top:      "0%",
 				Left:     "10%",
 			}),
+			charts.WithXAxisOpts(opts.XAxis{
+				Name: "Benchmark\n" + capitalize(layout.XAxis),
+				SplitLine: &opts.SplitLine{
+					Show: true,
+				},
+			}),
 		)...,
 	).SetSeriesOptions(
 		// 0 gap between bars in same scenario
 		charts.WithBarChartOpts(opts.BarChart{
-			BarGap: "0%",
+			BarGap:         "0%",
+			BarCategoryGap: fmt.Sprintf("%g%%", barCategoryGap*100),
 		}),
 	)
-	bar.SetXAxis(scenarios)
+	bar.ChartID = chartID
+
+	cells, seriesNames, xAxis := cellsOf(benchmarks, layout, metric)
+	// hidden value x axis over the category one, markers are placed on it at the center of their bar
+	bar.ExtendXAxis(opts.XAxis{
+		Type: "value", Min: -0.5, Max: float64(len(xAxis)) - 0.5,
+		AxisLabel: &opts.AxisLabel{Show: false}, AxisTick: &opts.AxisTick{Show: false}, SplitLine: &opts.SplitLine{Show: false},
+	})
+	categories := make(map[string]int, len(xAxis))
+	for idx, x := range xAxis {
+		categories[x] = idx
+	}
+	seriesIndexes := make(map[string]int, len(seriesNames))
+	for idx, series := range seriesNames {
+		seriesIndexes[series] = idx
+	}
+	// outlier runs of every series, shown as points over the bars
+	outliers := make(map[string][]opts.ScatterData)
+	for _, benchmark := range benchmarks {
+		for _, unit := range benchmark.Outliers {
+			if unit == metric.unit {
+				series := benchmark.Dimension(layout.Series)
+				barX := barCenter(categories[benchmark.Dimension(layout.XAxis)], seriesIndexes[series], len(seriesNames))
+				outliers[series] = append(outliers[series], opts.ScatterData{
+					Name: benchmark.Name, Value: []interface{}{barX, metric.value(benchmark)}, Symbol: "diamond", SymbolSize: 12,
+				})
+			}
+		}
+	}
+	bar.SetXAxis(xAxis)
+	// badges and error bars of every series, drawn by a series without data of the same name(sharing legend and color)
+	marks := charts.NewScatter()
+	for seriesIdx, series := range seriesNames {
+		data := make([]opts.BarData, 0, len(xAxis))
+		// badges over bars of statistically weak Benchmark
+		var badges []opts.MarkPointNameCoordItem
+		// error bars of sampled bars, from low to high
+		var errorBars []opts.MarkLineNameCoordItem
+		for idx, x := range xAxis {
+			c, ok := cells[[2]string{series, x}]
+			if !ok {
+				// echarts takes '-' as missing value
+				data = append(data, opts.BarData{Name: x, Value: "-"})
+				continue
+			}
+			data = append(data, opts.BarData{Name: x, Value: c.mean()})
+			barX := barCenter(idx, seriesIdx, len(seriesNames))
+			if len(c.warnings) > 0 {
+				badges = append(badges, opts.MarkPointNameCoordItem{
+					Name: strings.Join(SortValues(c.warnings.ToSlice()), ", "), Coordinate: []interface{}{barX, c.mean()},
+					Value: "!", Symbol: "pin", SymbolSize: 30, ItemStyle: &opts.ItemStyle{Color: warningColor},
+				})
+			}
+			if low, high, ok := c.errorBar(layout.ErrorBars); ok {
+				errorBars = append(errorBars, opts.MarkLineNameCoordItem{
+					Name:        fmt.Sprintf("%s %.6g ~ %.6g, %d samples", layout.ErrorBars, low, high, len(c.values)),
+					Coordinate0: []interface{}{barX, low}, Coordinate1: []interface{}{barX, high},
+				})
+			}
+		}
+		bar.AddSeries(series, data)
+		if len(badges) == 0 && len(errorBars) == 0 {
+			continue
+		}
+		seriesOpts := []charts.SeriesOpts{charts.WithScatterChartOpts(opts.ScatterChart{XAxisIndex: 1})}
+		if len(badges) > 0 {
+			seriesOpts = append(seriesOpts, charts.WithMarkPointNameCoordItemOpts(badges...))
+		}
+		if len(errorBars) > 0 {
+			seriesOpts = append(seriesOpts, charts.WithMarkLineNameCoordItemOpts(errorBars...),
+				charts.WithMarkLineStyleOpts(opts.MarkLineStyle{Symbol: []string{"none", "none"}, Label: &opts.Label{Show: false}}))
+		}
+		marks.AddSeries(series, []opts.ScatterData{}, seriesOpts...)
+	}
+	bar.Overlap(marks)
+	if len(outliers) > 0 {
+		scatter := charts.NewScatter()
+		for _, series := range seriesNames {
+			if len(outliers[series]) > 0 {
+				scatter.AddSeries(series+" outliers", outliers[series], charts.WithScatterChartOpts(opts.ScatterChart{XAxisIndex: 1}))
+			}
+		}
+		bar.Overlap(scatter)
+	}
+}
+
+// barCategoryGap gap between categories of bars, in ratio of the category width
+const barCategoryGap = 0.2
+
+// barCenter center of the bar of a series in a category on the value x axis, categories are centered at their index
+// and bars of all series share the rest of the category width without gap
+func barCenter(category int, series int, seriesCount int) float64 {
+	width := (1 - barCategoryGap) / float64(seriesCount)
+	return float64(category) - (1-barCategoryGap)/2 + width*(float64(series)+0.5)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// SortValues sort dimension values in place, numerically when all of them are numbers
+//
+//	@param values []string
+//	@return []string values itself
+//	@author kevineluo
+//	@update 2026-10-19 17:41:50
+func SortValues(values []string) []string {
+	numbers := make(map[string]float64, len(values))
+	for _, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			sort.Strings(values)
+			return values
+		}
+		numbers[value] = number
+	}
+	sort.Slice(values, func(i, j int) bool { return numbers[values[i]] < numbers[values[j]] })
+	return values
 }
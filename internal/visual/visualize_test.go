@@ -0,0 +1,150 @@
+package visual
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Kevinello/benchvisual/internal/bench"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewCharts(t *testing.T) {
+	convey.Convey("Given a Benchmark set with labels", t, func() {
+		labels := func(workload string) map[string]string { return map[string]string{"workload": workload} }
+		set := &bench.Set{Pkg: "demo", Targets: map[string]bench.BenchmarkList{
+			"Ants": {
+				{Target: "Ants", Scenario: "10", Labels: labels("rand"), NsPerOp: 10},
+				{Target: "Ants", Scenario: "10", Labels: labels("rand"), NsPerOp: 20},
+				{Target: "Ants", Scenario: "10", Labels: labels("sleep"), NsPerOp: 30},
+			},
+			"Pond": {
+				{Target: "Pond", Scenario: "2", Labels: labels("rand"), NsPerOp: 40},
+			},
+		}}
+
+		convey.Convey("Default layout puts targets in series and scenarios in x axis", func() {
+			barCharts := NewCharts(set, DefaultLayout)
+			convey.So(barCharts, convey.ShouldHaveLength, len(metrics))
+			chart := barCharts[0]
+			chart.Validate()
+			convey.So(chart.ChartID, convey.ShouldEqual, "ns_per_op")
+			convey.So(chart.XAxisList[0].Data, convey.ShouldResemble, []string{"2", "10"})
+			convey.So(chart.MultiSeries, convey.ShouldHaveLength, 2)
+			convey.So(chart.MultiSeries[0].Name, convey.ShouldEqual, "Ants")
+			convey.So(chart.MultiSeries[0].Data, convey.ShouldResemble, []opts.BarData{{Name: "2", Value: "-"}, {Name: "10", Value: float64(20)}})
+		})
+
+		convey.Convey("Charts are split by a label", func() {
+			barCharts := NewCharts(set, Layout{Series: "scenario", XAxis: "target", Split: "workload"})
+			convey.So(barCharts, convey.ShouldHaveLength, 2*len(metrics))
+			convey.So(barCharts[0].ChartID, convey.ShouldEqual, "ns_per_op-rand")
+			convey.So(barCharts[1].ChartID, convey.ShouldEqual, "ns_per_op-sleep")
+			barCharts[0].Validate()
+			convey.So(barCharts[0].XAxisList[0].Data, convey.ShouldResemble, []string{"Ants", "Pond"})
+			convey.So(barCharts[0].MultiSeries[0].Name, convey.ShouldEqual, "2")
+			convey.So(barCharts[0].MultiSeries[1].Data, convey.ShouldResemble, []opts.BarData{{Name: "Ants", Value: float64(15)}, {Name: "Pond", Value: "-"}})
+		})
+
+		convey.Convey("Outlier runs are shown as points over the bars", func() {
+			set.Targets["Ants"][1].Outliers = []string{"ns/op"}
+			barCharts := NewCharts(set, DefaultLayout)
+			convey.So(barCharts[0].MultiSeries, convey.ShouldHaveLength, 3)
+			convey.So(barCharts[0].MultiSeries[2].Name, convey.ShouldEqual, "Ants outliers")
+			convey.So(barCharts[0].MultiSeries[2].Type, convey.ShouldEqual, "scatter")
+			convey.So(barCharts[1].MultiSeries, convey.ShouldHaveLength, 2)
+		})
+
+		convey.Convey("Error bars are drawn over bars with repeated samples", func() {
+			chart := NewCharts(set, Layout{Series: "target", XAxis: "scenario", ErrorBars: ErrorBarsMinMax})[0]
+			// only Ants is sampled more than once
+			convey.So(chart.MultiSeries, convey.ShouldHaveLength, 3)
+			convey.So(chart.MultiSeries[0].MarkLines, convey.ShouldBeNil)
+			convey.So(chart.MultiSeries[2].Name, convey.ShouldEqual, "Ants")
+			convey.So(chart.MultiSeries[2].XAxisIndex, convey.ShouldEqual, 1)
+			errorBars := chart.MultiSeries[2].MarkLines.Data
+			convey.So(errorBars, convey.ShouldHaveLength, 1)
+			convey.So(fmt.Sprint(errorBars[0]), convey.ShouldContainSubstring, "minmax 10 ~ 30, 3 samples")
+
+			chart = NewCharts(set, Layout{Series: "target", XAxis: "scenario", ErrorBars: ErrorBarsCI})[0]
+			convey.So(chart.MultiSeries[2].MarkLines.Data, convey.ShouldHaveLength, 1)
+			convey.So(NewCharts(set, DefaultLayout)[0].MultiSeries, convey.ShouldHaveLength, 2)
+		})
+
+		convey.Convey("Box plots show the sample distribution", func() {
+			boxPlots := NewBoxPlots(set, DefaultLayout)
+			convey.So(boxPlots, convey.ShouldHaveLength, len(metrics))
+			boxPlot := boxPlots[0]
+			boxPlot.Validate()
+			convey.So(boxPlot.ChartID, convey.ShouldEqual, "ns_per_op_box")
+			convey.So(boxPlot.XAxisList[0].Data, convey.ShouldResemble, []string{"2", "10"})
+			convey.So(boxPlot.MultiSeries[0].Data, convey.ShouldResemble, []opts.BoxPlotData{
+				{Name: "2"}, {Name: "10", Value: []float64{10, 15, 20, 25, 30}},
+			})
+			convey.So(NewPage(set, DefaultLayout).Charts, convey.ShouldHaveLength, len(metrics))
+			convey.So(NewPage(set, Layout{Series: "target", XAxis: "scenario", BoxPlot: true}).Charts, convey.ShouldHaveLength, 2*len(metrics))
+		})
+
+		convey.Convey("Statistically weak Benchmark are badged and summarized", func() {
+			convey.So(NoiseSummary(set), convey.ShouldBeEmpty)
+			set.Targets["Pond"][0].Name = "BenchmarkPond/2"
+			set.Targets["Pond"][0].Warnings = []bench.NoiseWarning{{Kind: bench.WarningLowIterations, Message: "only 3 iterations in a run"}}
+			barCharts := NewCharts(set, DefaultLayout)
+			convey.So(barCharts[0].MultiSeries, convey.ShouldHaveLength, 3)
+			convey.So(barCharts[0].MultiSeries[2].Name, convey.ShouldEqual, "Pond")
+			badges := barCharts[0].MultiSeries[2].MarkPoints.Data
+			convey.So(badges, convey.ShouldHaveLength, 1)
+			convey.So(badges[0].(opts.MarkPointNameCoordItem).Name, convey.ShouldEqual, bench.WarningLowIterations)
+			// over the second bar of the first scenario
+			coordinate := badges[0].(opts.MarkPointNameCoordItem).Coordinate
+			convey.So(coordinate[0], convey.ShouldAlmostEqual, 0.2)
+			convey.So(coordinate[1], convey.ShouldEqual, float64(40))
+
+			summary := NoiseSummary(set)
+			convey.So(summary, convey.ShouldContainSubstring, "Reliability warnings")
+			convey.So(summary, convey.ShouldContainSubstring, "BenchmarkPond/2 map[workload:rand]")
+			convey.So(summary, convey.ShouldContainSubstring, "only 3 iterations in a run")
+		})
+	})
+}
+
+func TestBarMarkers(t *testing.T) {
+	convey.Convey("Given two targets sampled in the same scenario", t, func() {
+		set := &bench.Set{Pkg: "demo", Targets: map[string]bench.BenchmarkList{
+			"Ants": {
+				{Name: "BenchmarkAnts/10", Target: "Ants", Scenario: "10", NsPerOp: 10},
+				{Name: "BenchmarkAnts/10", Target: "Ants", Scenario: "10", NsPerOp: 20, Outliers: []string{"ns/op"}},
+			},
+			"Pond": {
+				{Name: "BenchmarkPond/10", Target: "Pond", Scenario: "10", NsPerOp: 30},
+				{Name: "BenchmarkPond/10", Target: "Pond", Scenario: "10", NsPerOp: 40, Outliers: []string{"ns/op"}},
+			},
+		}}
+
+		convey.Convey("Error bars and outliers are placed over the bar of their series", func() {
+			chart := NewCharts(set, Layout{Series: "target", XAxis: "scenario", ErrorBars: ErrorBarsMinMax})[0]
+			convey.So(chart.XAxisList, convey.ShouldHaveLength, 2)
+			convey.So(chart.XAxisList[1].Min, convey.ShouldEqual, -0.5)
+			convey.So(chart.XAxisList[1].Max, convey.ShouldEqual, 0.5)
+			convey.So(chart.MultiSeries, convey.ShouldHaveLength, 6)
+
+			errorBarX := make([]interface{}, 0, 2)
+			for _, series := range chart.MultiSeries[2:4] {
+				// a mark line is a pair of points
+				var errorBar []struct{ Coord []float64 }
+				data, err := jsoniter.Marshal(series.MarkLines.Data[0])
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(jsoniter.Unmarshal(data, &errorBar), convey.ShouldBeNil)
+				convey.So(errorBar[0].Coord[0], convey.ShouldEqual, errorBar[1].Coord[0])
+				errorBarX = append(errorBarX, errorBar[0].Coord[0])
+			}
+			convey.So(errorBarX[0], convey.ShouldAlmostEqual, -0.2)
+			convey.So(errorBarX[1], convey.ShouldAlmostEqual, 0.2)
+
+			convey.So(chart.MultiSeries[4].Name, convey.ShouldEqual, "Ants outliers")
+			convey.So(chart.MultiSeries[4].Data.([]opts.ScatterData)[0].Value, convey.ShouldResemble, []interface{}{errorBarX[0], float64(20)})
+			convey.So(chart.MultiSeries[5].Data.([]opts.ScatterData)[0].Value, convey.ShouldResemble, []interface{}{errorBarX[1], float64(40)})
+		})
+	})
+}